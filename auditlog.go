@@ -1,5 +1,15 @@
 package disgord
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/andersfylling/disgord/json"
+)
+
 type AuditLogEvt uint
 
 // Audit-log event types
@@ -31,7 +41,7 @@ const (
 	AuditLogEvtRoleDelete
 )
 const (
-	AuditLogEvtInviteCreate AuditLogEvt = 40
+	AuditLogEvtInviteCreate AuditLogEvt = 40 + iota
 	AuditLogEvtInviteUpdate
 	AuditLogEvtInviteDelete
 )
@@ -46,7 +56,33 @@ const (
 	AuditLogEvtEmojiDelete
 )
 const (
-	AuditLogEvtMessageDelete AuditLogEvt = 72
+	AuditLogEvtMessageDelete AuditLogEvt = 72 + iota
+	AuditLogEvtMessageBulkDelete
+	AuditLogEvtMessagePin
+	AuditLogEvtMessageUnpin
+)
+const (
+	AuditLogEvtIntegrationCreate AuditLogEvt = 80 + iota
+	AuditLogEvtIntegrationUpdate
+	AuditLogEvtIntegrationDelete
+	AuditLogEvtStageInstanceCreate
+	AuditLogEvtStageInstanceUpdate
+	AuditLogEvtStageInstanceDelete
+)
+const (
+	AuditLogEvtStickerCreate AuditLogEvt = 90 + iota
+	AuditLogEvtStickerUpdate
+	AuditLogEvtStickerDelete
+)
+const (
+	AuditLogEvtGuildScheduledEventCreate AuditLogEvt = 100 + iota
+	AuditLogEvtGuildScheduledEventUpdate
+	AuditLogEvtGuildScheduledEventDelete
+)
+const (
+	AuditLogEvtThreadCreate AuditLogEvt = 110 + iota
+	AuditLogEvtThreadUpdate
+	AuditLogEvtThreadDelete
 )
 
 type AuditLogChange string
@@ -96,12 +132,127 @@ const (
 	AuditLogChangeAvatarHash                  AuditLogChange = "avatar_hash"                   // user	string	user avatar changed
 	AuditLogChangeID                          AuditLogChange = "id"                            // any	snowflake	the id of the changed entity - sometimes used in conjunction with other keys
 	AuditLogChangeType                        AuditLogChange = "type"                          // any	integer (channel type) or string	type of entity created
+	AuditLogChangeDescription                 AuditLogChange = "description"                   // guild, sticker	string	description changed
+	AuditLogChangeBannerHash                  AuditLogChange = "banner_hash"                   // guild	string	guild banner changed
+	AuditLogChangeDiscoverySplashHash         AuditLogChange = "discovery_splash_hash"         // guild	string	discovery splash changed
+	AuditLogChangeSystemChannelID             AuditLogChange = "system_channel_id"             // guild	snowflake	id of the system channel changed
+	AuditLogChangeRulesChannelID              AuditLogChange = "rules_channel_id"              // guild	snowflake	id of the rules channel changed
+	AuditLogChangePublicUpdatesChannelID      AuditLogChange = "public_updates_channel_id"     // guild	snowflake	id of the public updates channel changed
+	AuditLogChangePreferredLocale             AuditLogChange = "preferred_locale"              // guild	string	preferred locale changed
+	AuditLogChangeRateLimitPerUser            AuditLogChange = "rate_limit_per_user"           // channel, thread	integer	amount of seconds a user has to wait before sending another message changed
+	AuditLogChangeUserLimit                   AuditLogChange = "user_limit"                    // voice channel	integer	max number of users changed
+	AuditLogChangeArchived                    AuditLogChange = "archived"                      // thread	bool	thread is now archived/unarchived
+	AuditLogChangeLocked                      AuditLogChange = "locked"                        // thread	bool	thread is now locked/unlocked
+	AuditLogChangeAutoArchiveDuration         AuditLogChange = "auto_archive_duration"         // thread	integer	auto archive duration changed
+	AuditLogChangeDefaultAutoArchiveDuration  AuditLogChange = "default_auto_archive_duration" // channel	integer	default auto archive duration for new threads changed
+	AuditLogChangeInvitable                   AuditLogChange = "invitable"                     // thread	bool	non-moderators can now add other non-moderators to the thread
+	AuditLogChangeCommunicationDisabledUntil  AuditLogChange = "communication_disabled_until"  // member	ISO8601 timestamp	member timeout changed
+	AuditLogChangePrivacyLevel                AuditLogChange = "privacy_level"                 // stage instance, scheduled event	integer	privacy level changed
+	AuditLogChangeStatus                      AuditLogChange = "status"                        // scheduled event	integer	status changed
+	AuditLogChangeEntityType                  AuditLogChange = "entity_type"                   // scheduled event	integer	entity type changed
+	AuditLogChangeLocation                    AuditLogChange = "location"                      // scheduled event	string	location changed
+	AuditLogChangeTags                        AuditLogChange = "tags"                          // sticker	string	related emoji of the sticker changed
+	AuditLogChangeFormatType                  AuditLogChange = "format_type"                   // sticker	integer	format type of the sticker changed
+	AuditLogChangeAvailable                   AuditLogChange = "available"                     // sticker	bool	sticker is now available/unavailable
+	AuditLogChangeGuildID                     AuditLogChange = "guild_id"                      // sticker	snowflake	guild the sticker is in changed
+	AuditLogChangeEnableEmoticons             AuditLogChange = "enable_emoticons"              // integration	bool	integration emoticons enabled/disabled
+	AuditLogChangeExpireBehavior              AuditLogChange = "expire_behavior"               // integration	integer	integration expiring subscriber behavior changed
+	AuditLogChangeExpireGracePeriod           AuditLogChange = "expire_grace_period"           // integration	integer	integration expire grace period changed
+	AuditLogChangeUnicodeEmoji                AuditLogChange = "unicode_emoji"                 // role	string	role unicode emoji changed
+)
+
+// auditLogValueKind describes which Go type the values of a audit log change key are decoded into.
+type auditLogValueKind uint8
+
+const (
+	auditLogValueRaw auditLogValueKind = iota
+	auditLogValueString
+	auditLogValueSnowflake
+	auditLogValueInt
+	auditLogValueBool
+	auditLogValuePermissions
+	auditLogValueRoles
+	auditLogValueOverwrites
+	auditLogValueTime
 )
 
+var auditLogChangeKinds = map[AuditLogChange]auditLogValueKind{
+	AuditLogChangeName:                        auditLogValueString,
+	AuditLogChangeIconHash:                    auditLogValueString,
+	AuditLogChangeSplashHash:                  auditLogValueString,
+	AuditLogChangeOwnerID:                     auditLogValueSnowflake,
+	AuditLogChangeRegion:                      auditLogValueString,
+	AuditLogChangeAFKChannelID:                auditLogValueSnowflake,
+	AuditLogChangeAFKTimeout:                  auditLogValueInt,
+	AuditLogChangeMFALevel:                    auditLogValueInt,
+	AuditLogChangeVerificationLevel:           auditLogValueInt,
+	AuditLogChangeExplicitContentFilter:       auditLogValueInt,
+	AuditLogChangeDefaultMessageNotifications: auditLogValueInt,
+	AuditLogChangeVanityURLCode:               auditLogValueString,
+	AuditLogChangeAdd:                         auditLogValueRoles,
+	AuditLogChangeRemove:                      auditLogValueRoles,
+	AuditLogChangePruneDeleteDays:             auditLogValueInt,
+	AuditLogChangeWidgetEnabled:               auditLogValueBool,
+	AuditLogChangeWidgetChannelID:             auditLogValueSnowflake,
+	AuditLogChangePosition:                    auditLogValueInt,
+	AuditLogChangeTopic:                       auditLogValueString,
+	AuditLogChangeBitrate:                     auditLogValueInt,
+	AuditLogChangePermissionOverwrites:        auditLogValueOverwrites,
+	AuditLogChangeNSFW:                        auditLogValueBool,
+	AuditLogChangeApplicationID:               auditLogValueSnowflake,
+	AuditLogChangePermissions:                 auditLogValuePermissions,
+	AuditLogChangeColor:                       auditLogValueInt,
+	AuditLogChangeHoist:                       auditLogValueBool,
+	AuditLogChangeMentionable:                 auditLogValueBool,
+	AuditLogChangeAllow:                       auditLogValuePermissions,
+	AuditLogChangeDeny:                        auditLogValuePermissions,
+	AuditLogChangeCode:                        auditLogValueString,
+	AuditLogChangeChannelID:                   auditLogValueSnowflake,
+	AuditLogChangeInviterID:                   auditLogValueSnowflake,
+	AuditLogChangeMaxUses:                     auditLogValueInt,
+	AuditLogChangeUses:                        auditLogValueInt,
+	AuditLogChangeMaxAge:                      auditLogValueInt,
+	AuditLogChangeTemporary:                   auditLogValueBool,
+	AuditLogChangeDeaf:                        auditLogValueBool,
+	AuditLogChangeMute:                        auditLogValueBool,
+	AuditLogChangeNick:                        auditLogValueString,
+	AuditLogChangeAvatarHash:                  auditLogValueString,
+	AuditLogChangeID:                          auditLogValueSnowflake,
+	AuditLogChangeType:                        auditLogValueRaw,
+	AuditLogChangeDescription:                 auditLogValueString,
+	AuditLogChangeBannerHash:                  auditLogValueString,
+	AuditLogChangeDiscoverySplashHash:         auditLogValueString,
+	AuditLogChangeSystemChannelID:             auditLogValueSnowflake,
+	AuditLogChangeRulesChannelID:              auditLogValueSnowflake,
+	AuditLogChangePublicUpdatesChannelID:      auditLogValueSnowflake,
+	AuditLogChangePreferredLocale:             auditLogValueString,
+	AuditLogChangeRateLimitPerUser:            auditLogValueInt,
+	AuditLogChangeUserLimit:                   auditLogValueInt,
+	AuditLogChangeArchived:                    auditLogValueBool,
+	AuditLogChangeLocked:                      auditLogValueBool,
+	AuditLogChangeAutoArchiveDuration:         auditLogValueInt,
+	AuditLogChangeDefaultAutoArchiveDuration:  auditLogValueInt,
+	AuditLogChangeInvitable:                   auditLogValueBool,
+	AuditLogChangeCommunicationDisabledUntil:  auditLogValueTime,
+	AuditLogChangePrivacyLevel:                auditLogValueInt,
+	AuditLogChangeStatus:                      auditLogValueInt,
+	AuditLogChangeEntityType:                  auditLogValueInt,
+	AuditLogChangeLocation:                    auditLogValueString,
+	AuditLogChangeTags:                        auditLogValueString,
+	AuditLogChangeFormatType:                  auditLogValueInt,
+	AuditLogChangeAvailable:                   auditLogValueBool,
+	AuditLogChangeGuildID:                     auditLogValueSnowflake,
+	AuditLogChangeEnableEmoticons:             auditLogValueBool,
+	AuditLogChangeExpireBehavior:              auditLogValueInt,
+	AuditLogChangeExpireGracePeriod:           auditLogValueInt,
+	AuditLogChangeUnicodeEmoji:                auditLogValueString,
+}
+
 // AuditLog ...
 type AuditLog struct {
 	Webhooks        []*Webhook       `json:"webhooks"`
 	Users           []*User          `json:"users"`
+	Threads         []*Channel       `json:"threads"`
 	AuditLogEntries []*AuditLogEntry `json:"audit_log_entries"`
 }
 
@@ -121,6 +272,103 @@ func (l *AuditLog) Bans() (bans []*PartialBan) {
 	return bans
 }
 
+func (l *AuditLog) user(id Snowflake) *User {
+	for i := range l.Users {
+		if l.Users[i] != nil && l.Users[i].ID == id {
+			return l.Users[i]
+		}
+	}
+	return nil
+}
+
+func (l *AuditLog) webhook(id Snowflake) *Webhook {
+	for i := range l.Webhooks {
+		if l.Webhooks[i] != nil && l.Webhooks[i].ID == id {
+			return l.Webhooks[i]
+		}
+	}
+	return nil
+}
+
+func (l *AuditLog) thread(id Snowflake) *Channel {
+	for i := range l.Threads {
+		if l.Threads[i] != nil && l.Threads[i].ID == id {
+			return l.Threads[i]
+		}
+	}
+	return nil
+}
+
+// View pairs the entry with the user that executed the action, and the target object the action was
+// applied to. Only users, webhooks and threads are included in an audit log, any other target
+// must be looked up using the TargetID.
+func (l *AuditLog) View(entry *AuditLogEntry) *AuditLogEntryView {
+	view := &AuditLogEntryView{
+		AuditLogEntry: entry,
+		Executor:      l.user(entry.UserID),
+	}
+	if entry.TargetID.IsZero() {
+		return view
+	}
+
+	switch entry.Event {
+	case AuditLogEvtMemberKick, AuditLogEvtMemberBanAdd, AuditLogEvtMemberBanRemove, AuditLogEvtMemberUpdate,
+		AuditLogEvtMemberRoleUpdate, AuditLogEvtBotAdd, AuditLogEvtMessageDelete, AuditLogEvtMessagePin,
+		AuditLogEvtMessageUnpin:
+		if usr := l.user(entry.TargetID); usr != nil {
+			view.Target = usr
+		}
+	case AuditLogEvtWebhookCreate, AuditLogEvtWebhookUpdate, AuditLogEvtWebhookDelete:
+		if wh := l.webhook(entry.TargetID); wh != nil {
+			view.Target = wh
+		}
+	case AuditLogEvtThreadCreate, AuditLogEvtThreadUpdate, AuditLogEvtThreadDelete:
+		if thread := l.thread(entry.TargetID); thread != nil {
+			view.Target = thread
+		}
+	}
+	return view
+}
+
+// Views creates a view for every audit log entry, in the same order as AuditLogEntries.
+func (l *AuditLog) Views() []*AuditLogEntryView {
+	views := make([]*AuditLogEntryView, 0, len(l.AuditLogEntries))
+	for i := range l.AuditLogEntries {
+		views = append(views, l.View(l.AuditLogEntries[i]))
+	}
+	return views
+}
+
+// AuditLogEntryView is an audit log entry with the referenced objects resolved from the audit log.
+type AuditLogEntryView struct {
+	*AuditLogEntry
+
+	// Executor is the user that made the changes. Nil if the user was not included in the audit log.
+	Executor *User
+
+	// Target is either a *User, *Webhook or *Channel (thread) depending on the event type.
+	// Nil when the target is of a different type, or could not be found in the audit log.
+	Target interface{}
+}
+
+// TargetUser returns the target when it is a user, otherwise nil.
+func (v *AuditLogEntryView) TargetUser() *User {
+	usr, _ := v.Target.(*User)
+	return usr
+}
+
+// TargetWebhook returns the target when it is a webhook, otherwise nil.
+func (v *AuditLogEntryView) TargetWebhook() *Webhook {
+	wh, _ := v.Target.(*Webhook)
+	return wh
+}
+
+// TargetThread returns the target when it is a thread, otherwise nil.
+func (v *AuditLogEntryView) TargetThread() *Channel {
+	thread, _ := v.Target.(*Channel)
+	return thread
+}
+
 // AuditLogEntry ...
 type AuditLogEntry struct {
 	TargetID Snowflake          `json:"target_id"`
@@ -135,6 +383,16 @@ type AuditLogEntry struct {
 var _ Copier = (*AuditLogEntry)(nil)
 var _ DeepCopier = (*AuditLogEntry)(nil)
 
+// Change returns the first change with the given key, or nil if the entry has no such change.
+func (e *AuditLogEntry) Change(key AuditLogChange) *AuditLogChanges {
+	for i := range e.Changes {
+		if e.Changes[i] != nil && AuditLogChange(e.Changes[i].Key) == key {
+			return e.Changes[i]
+		}
+	}
+	return nil
+}
+
 // AuditLogOption ...
 type AuditLogOption struct {
 	DeleteMemberDays string    `json:"delete_member_days"`
@@ -159,6 +417,327 @@ type AuditLogChanges struct {
 var _ Copier = (*AuditLogChanges)(nil)
 var _ DeepCopier = (*AuditLogChanges)(nil)
 
+var ErrAuditLogChangeType = errors.New("audit log change key does not hold the requested type")
+
+// Decode decodes the old and new value of the change into the given pointers, using the same json rules as
+// the remaining Discord objects. Either pointer can be nil to skip that value. Values that are missing, as
+// OldValue is for newly created entities, leaves the destination untouched.
+func (c *AuditLogChanges) Decode(oldValue, newValue interface{}) error {
+	if err := decodeAuditLogValue(c.OldValue, oldValue); err != nil {
+		return fmt.Errorf("old_value of %s: %w", c.Key, err)
+	}
+	if err := decodeAuditLogValue(c.NewValue, newValue); err != nil {
+		return fmt.Errorf("new_value of %s: %w", c.Key, err)
+	}
+	return nil
+}
+
+func decodeAuditLogValue(v interface{}, dst interface{}) error {
+	if v == nil || dst == nil {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
+
+func (c *AuditLogChanges) expect(kind auditLogValueKind) error {
+	if known, ok := auditLogChangeKinds[AuditLogChange(c.Key)]; ok && known != kind {
+		return fmt.Errorf("%s: %w", c.Key, ErrAuditLogChangeType)
+	}
+	return nil
+}
+
+// StringValues decodes changes such as name, topic, nick and the different hashes.
+func (c *AuditLogChanges) StringValues() (before, after string, err error) {
+	if err = c.expect(auditLogValueString); err != nil {
+		return "", "", err
+	}
+	err = c.Decode(&before, &after)
+	return before, after, err
+}
+
+// SnowflakeValues decodes changes holding an ID, such as owner_id and channel_id.
+func (c *AuditLogChanges) SnowflakeValues() (before, after Snowflake, err error) {
+	if err = c.expect(auditLogValueSnowflake); err != nil {
+		return 0, 0, err
+	}
+	err = c.Decode(&before, &after)
+	return before, after, err
+}
+
+// IntValues decodes numeric changes, such as position, bitrate, color and the different guild levels.
+func (c *AuditLogChanges) IntValues() (before, after int, err error) {
+	if err = c.expect(auditLogValueInt); err != nil {
+		return 0, 0, err
+	}
+	err = c.Decode(&before, &after)
+	return before, after, err
+}
+
+// BoolValues decodes changes such as nsfw, hoist, mute and deaf.
+func (c *AuditLogChanges) BoolValues() (before, after bool, err error) {
+	if err = c.expect(auditLogValueBool); err != nil {
+		return false, false, err
+	}
+	err = c.Decode(&before, &after)
+	return before, after, err
+}
+
+// PermissionValues decodes the permissions, allow and deny changes.
+func (c *AuditLogChanges) PermissionValues() (before, after PermissionBit, err error) {
+	if err = c.expect(auditLogValuePermissions); err != nil {
+		return 0, 0, err
+	}
+	if before, err = auditLogPermissionBit(c.OldValue); err != nil {
+		return 0, 0, fmt.Errorf("old_value of %s: %w", c.Key, err)
+	}
+	if after, err = auditLogPermissionBit(c.NewValue); err != nil {
+		return 0, 0, fmt.Errorf("new_value of %s: %w", c.Key, err)
+	}
+	return before, after, nil
+}
+
+// RoleValues decodes the partial roles of the $add and $remove changes. Only the role ID and name is set.
+func (c *AuditLogChanges) RoleValues() (before, after []*Role, err error) {
+	if err = c.expect(auditLogValueRoles); err != nil {
+		return nil, nil, err
+	}
+	err = c.Decode(&before, &after)
+	return before, after, err
+}
+
+// OverwriteValues decodes the permission_overwrites change.
+func (c *AuditLogChanges) OverwriteValues() (before, after []PermissionOverwrite, err error) {
+	if err = c.expect(auditLogValueOverwrites); err != nil {
+		return nil, nil, err
+	}
+	if before, err = auditLogOverwrites(c.OldValue); err != nil {
+		return nil, nil, fmt.Errorf("old_value of %s: %w", c.Key, err)
+	}
+	if after, err = auditLogOverwrites(c.NewValue); err != nil {
+		return nil, nil, fmt.Errorf("new_value of %s: %w", c.Key, err)
+	}
+	return before, after, nil
+}
+
+// TimeValues decodes timestamp changes, such as communication_disabled_until.
+func (c *AuditLogChanges) TimeValues() (before, after Time, err error) {
+	if err = c.expect(auditLogValueTime); err != nil {
+		return Time{}, Time{}, err
+	}
+	err = c.Decode(&before, &after)
+	return before, after, err
+}
+
+// Values decodes the old and new value into the Go type that matches the change key: string, Snowflake, int,
+// bool, PermissionBit, []*Role, []PermissionOverwrite or Time. Unknown keys, and the "type" key which
+// can be either a number or a string, are returned as is.
+func (c *AuditLogChanges) Values() (before, after interface{}, err error) {
+	switch auditLogChangeKinds[AuditLogChange(c.Key)] {
+	case auditLogValueString:
+		return pairOrErr(c.StringValues())
+	case auditLogValueSnowflake:
+		return pairOrErr(c.SnowflakeValues())
+	case auditLogValueInt:
+		return pairOrErr(c.IntValues())
+	case auditLogValueBool:
+		return pairOrErr(c.BoolValues())
+	case auditLogValuePermissions:
+		return pairOrErr(c.PermissionValues())
+	case auditLogValueRoles:
+		return pairOrErr(c.RoleValues())
+	case auditLogValueOverwrites:
+		return pairOrErr(c.OverwriteValues())
+	case auditLogValueTime:
+		return pairOrErr(c.TimeValues())
+	default:
+		return c.OldValue, c.NewValue, nil
+	}
+}
+
+func pairOrErr(before, after interface{}, err error) (interface{}, interface{}, error) {
+	if err != nil {
+		return nil, nil, err
+	}
+	return before, after, nil
+}
+
+// auditLogPermissionBit accepts permissions as both strings and numbers, as older audit log entries
+// were created before permissions were serialized as strings.
+func auditLogPermissionBit(v interface{}) (PermissionBit, error) {
+	switch t := v.(type) {
+	case nil:
+		return 0, nil
+	case string:
+		bits, err := strconv.ParseUint(t, 10, 64)
+		return PermissionBit(bits), err
+	case float64:
+		return PermissionBit(t), nil
+	default:
+		return 0, fmt.Errorf("unexpected permission value %v: %w", v, ErrAuditLogChangeType)
+	}
+}
+
+// auditLogOverwrites decodes overwrites where the type might be a number, a numeric string or one of the
+// legacy values "role" and "member". See the warning on PermissionOverwrite.
+func auditLogOverwrites(v interface{}) ([]PermissionOverwrite, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var raw []struct {
+		ID    Snowflake   `json:"id"`
+		Type  interface{} `json:"type"`
+		Allow interface{} `json:"allow"`
+		Deny  interface{} `json:"deny"`
+	}
+	if err := decodeAuditLogValue(v, &raw); err != nil {
+		return nil, err
+	}
+
+	overwrites := make([]PermissionOverwrite, 0, len(raw))
+	for i := range raw {
+		overwrite := PermissionOverwrite{ID: raw[i].ID}
+		switch t := raw[i].Type.(type) {
+		case float64:
+			overwrite.Type = PermissionOverwriteType(t)
+		case string:
+			switch t {
+			case "role", "0":
+				overwrite.Type = PermissionOverwriteRole
+			case "member", "1":
+				overwrite.Type = PermissionOverwriteMember
+			default:
+				return nil, fmt.Errorf("unknown overwrite type %q: %w", t, ErrAuditLogChangeType)
+			}
+		}
+
+		var err error
+		if overwrite.Allow, err = auditLogPermissionBit(raw[i].Allow); err != nil {
+			return nil, err
+		}
+		if overwrite.Deny, err = auditLogPermissionBit(raw[i].Deny); err != nil {
+			return nil, err
+		}
+		overwrites = append(overwrites, overwrite)
+	}
+	return overwrites, nil
+}
+
+// IterateAuditLogs configures a AuditLogIterator. By default the iterator backfills, walking from the
+// newest entry towards the oldest.
+type IterateAuditLogs struct {
+	UserID     Snowflake
+	ActionType AuditLogEvt
+
+	// Before is the entry ID to start backfilling from. Zero starts from the newest entry.
+	Before Snowflake
+
+	// Follow makes the iterator walk towards newer entries instead, starting after the entry ID given in After.
+	// When After is zero, only entries created after the iterator started are returned. Once caught up,
+	// the audit log is polled every PollInterval until the context is cancelled.
+	Follow       bool
+	After        Snowflake
+	PollInterval time.Duration
+
+	// PageSize is the number of entries requested at the time, between 1 and 100. Defaults to 100.
+	PageSize int
+
+	// Until stops the iteration when it returns true. The entry is not returned.
+	Until func(entry *AuditLogEntry) bool
+}
+
+const (
+	auditLogPageSizeMax         = 100
+	auditLogPollIntervalDefault = 30 * time.Second
+)
+
+// AuditLogIterator walks the audit log of a guild without gaps between pages. It is a poll based fallback
+// for the GUILD_AUDIT_LOG_ENTRY_CREATE event, and can backfill entries created before the bot connected.
+type AuditLogIterator struct {
+	it      *cursorIterator
+	started bool
+}
+
+// IterateAuditLogs creates a iterator for the guild audit log. The default direction is backward, from
+// the newest entry to the oldest. Requires the 'VIEW_AUDIT_LOG' permission.
+func (g guildQueryBuilder) IterateAuditLogs(params *IterateAuditLogs) *AuditLogIterator {
+	if params == nil {
+		params = &IterateAuditLogs{}
+	}
+	pagination := Pagination{PageSize: params.PageSize, Direction: PaginateBackward, Cursor: params.Before}
+	if params.Follow {
+		pagination.Direction = PaginateForward
+		pagination.Cursor = params.After
+	}
+
+	fetch := func(ctx context.Context, before, after Snowflake, limit int) ([]interface{}, error) {
+		log, err := g.WithContext(ctx).GetAuditLogs(&GetAuditLogs{
+			UserID:     params.UserID,
+			ActionType: int(params.ActionType),
+			Before:     before,
+			After:      after,
+			Limit:      limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, len(log.AuditLogEntries))
+		for i := range log.AuditLogEntries {
+			items[i] = log.AuditLogEntries[i]
+		}
+		return items, nil
+	}
+	id := func(item interface{}) Snowflake {
+		return item.(*AuditLogEntry).ID
+	}
+
+	it := newCursorIterator(pagination, PaginateBackward, auditLogPageSizeMax, fetch, id)
+	if params.Until != nil {
+		until := params.Until
+		it.until = func(item interface{}) bool {
+			return until(item.(*AuditLogEntry))
+		}
+	}
+	if params.Follow {
+		it.poll = params.PollInterval
+		if it.poll <= 0 {
+			it.poll = auditLogPollIntervalDefault
+		}
+	}
+	return &AuditLogIterator{
+		it:      it,
+		started: !params.Follow || !pagination.Cursor.IsZero(),
+	}
+}
+
+// Next returns the next audit log entry. ErrIteratorDone is returned after the last entry. When following,
+// Next blocks until a new entry is created or the context is cancelled.
+func (a *AuditLogIterator) Next(ctx context.Context) (*AuditLogEntry, error) {
+	if !a.started {
+		// only entries created from now on are of interest
+		entries, err := a.it.fetch(ctx, 0, 0, 1)
+		if err != nil {
+			return nil, err
+		}
+		if len(entries) > 0 {
+			a.it.cursor = a.it.id(entries[0])
+		} else {
+			a.it.cursor = SnowflakeFromTime(time.Now())
+		}
+		a.it.caughtUp = true
+		a.started = true
+	}
+
+	item, err := a.it.next(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return item.(*AuditLogEntry), nil
+}
+
 // auditLogFactory temporary until flyweight is implemented
 func auditLogFactory() interface{} {
 	return &AuditLog{}
@@ -4,7 +4,16 @@
 package disgord
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
 	"testing"
+	"time"
+
+	"github.com/andersfylling/disgord/json"
 )
 
 func TestAuditLog_InterfaceImplementations(t *testing.T) {
@@ -65,3 +74,191 @@ func TestAuditLog_InterfaceImplementations(t *testing.T) {
 		})
 	})
 }
+
+func TestAuditLogChanges_TypedValues(t *testing.T) {
+	data := []byte(`{
+		"users": [{"id": "1", "username": "mod"}, {"id": "2", "username": "member"}],
+		"webhooks": [{"id": "3", "name": "hook"}],
+		"threads": [{"id": "4", "name": "thread"}],
+		"audit_log_entries": [
+			{"id": "10", "user_id": "1", "target_id": "2", "action_type": 25, "changes": [
+				{"key": "$add", "new_value": [{"id": "5", "name": "role"}]}
+			]},
+			{"id": "11", "user_id": "1", "target_id": "6", "action_type": 11, "changes": [
+				{"key": "name", "old_value": "general", "new_value": "chat"},
+				{"key": "nsfw", "old_value": false, "new_value": true},
+				{"key": "position", "old_value": 1, "new_value": 2},
+				{"key": "permission_overwrites", "new_value": [{"id": "5", "type": "role", "allow": "1024", "deny": 0}]}
+			]},
+			{"id": "12", "user_id": "1", "target_id": "3", "action_type": 50},
+			{"id": "13", "user_id": "1", "target_id": "4", "action_type": 111, "changes": [
+				{"key": "archived", "old_value": false, "new_value": true}
+			]},
+			{"id": "14", "user_id": "1", "target_id": "5", "action_type": 31, "changes": [
+				{"key": "permissions", "old_value": "0", "new_value": "8"}
+			]}
+		]
+	}`)
+
+	log := &AuditLog{}
+	if err := json.Unmarshal(data, log); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("roles", func(t *testing.T) {
+		_, added, err := log.AuditLogEntries[0].Change(AuditLogChangeAdd).RoleValues()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(added) != 1 || added[0].ID != 5 || added[0].Name != "role" {
+			t.Errorf("unexpected roles %+v", added)
+		}
+	})
+
+	t.Run("channel update", func(t *testing.T) {
+		entry := log.AuditLogEntries[1]
+		if before, after, err := entry.Change(AuditLogChangeName).StringValues(); err != nil || before != "general" || after != "chat" {
+			t.Errorf("name: got %q -> %q, %v", before, after, err)
+		}
+		if before, after, err := entry.Change(AuditLogChangeNSFW).BoolValues(); err != nil || before || !after {
+			t.Errorf("nsfw: got %t -> %t, %v", before, after, err)
+		}
+		if before, after, err := entry.Change(AuditLogChangePosition).Values(); err != nil || before.(int) != 1 || after.(int) != 2 {
+			t.Errorf("position: got %v -> %v, %v", before, after, err)
+		}
+		if _, _, err := entry.Change(AuditLogChangeName).IntValues(); !errors.Is(err, ErrAuditLogChangeType) {
+			t.Errorf("expected type error, got %v", err)
+		}
+
+		before, after, err := entry.Change(AuditLogChangePermissionOverwrites).OverwriteValues()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if before != nil {
+			t.Errorf("expected no old overwrites, got %+v", before)
+		}
+		expected := PermissionOverwrite{ID: 5, Type: PermissionOverwriteRole, Allow: PermissionReadMessages}
+		if len(after) != 1 || after[0] != expected {
+			t.Errorf("expected overwrite %+v, got %+v", expected, after)
+		}
+	})
+
+	t.Run("permissions", func(t *testing.T) {
+		before, after, err := log.AuditLogEntries[4].Change(AuditLogChangePermissions).PermissionValues()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if before != 0 || after != PermissionAdministrator {
+			t.Errorf("got %d -> %d", before, after)
+		}
+	})
+
+	t.Run("views", func(t *testing.T) {
+		views := log.Views()
+		if len(views) != len(log.AuditLogEntries) {
+			t.Fatalf("expected %d views, got %d", len(log.AuditLogEntries), len(views))
+		}
+		if views[0].Executor == nil || views[0].Executor.ID != 1 {
+			t.Error("executor was not resolved")
+		}
+		if usr := views[0].TargetUser(); usr == nil || usr.ID != 2 {
+			t.Error("target user was not resolved")
+		}
+		if views[1].Target != nil {
+			t.Error("channel targets are not part of the audit log")
+		}
+		if wh := views[2].TargetWebhook(); wh == nil || wh.ID != 3 {
+			t.Error("target webhook was not resolved")
+		}
+		if thread := views[3].TargetThread(); thread == nil || thread.ID != 4 {
+			t.Error("target thread was not resolved")
+		}
+	})
+}
+
+// auditLogDoer serves audit log entries with the IDs 1 to n, newest first like Discord
+type auditLogDoer struct {
+	n int
+}
+
+func (d *auditLogDoer) Do(req *http.Request) (*http.Response, error) {
+	query := req.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	before, _ := strconv.Atoi(query.Get("before"))
+	after, _ := strconv.Atoi(query.Get("after"))
+
+	log := &AuditLog{}
+	if query.Get("after") != "" {
+		for id := after + 1; id <= d.n && len(log.AuditLogEntries) < limit; id++ {
+			log.AuditLogEntries = append([]*AuditLogEntry{{ID: Snowflake(id)}}, log.AuditLogEntries...)
+		}
+	} else {
+		if before == 0 {
+			before = d.n + 1
+		}
+		for id := before - 1; id > 0 && len(log.AuditLogEntries) < limit; id-- {
+			log.AuditLogEntries = append(log.AuditLogEntries, &AuditLogEntry{ID: Snowflake(id)})
+		}
+	}
+
+	body, _ := json.Marshal(log)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func TestAuditLogIterator(t *testing.T) {
+	doer := &auditLogDoer{n: 5}
+	client, err := NewClient(context.Background(), Config{
+		BotToken:     "testing",
+		DisableCache: true,
+		HttpClient:   doer,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	guild := client.Guild(1)
+
+	t.Run("backfill", func(t *testing.T) {
+		it := guild.IterateAuditLogs(&IterateAuditLogs{PageSize: 2})
+		for expected := Snowflake(5); expected > 0; expected-- {
+			entry, err := it.Next(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if entry.ID != expected {
+				t.Errorf("expected entry %d, got %d", expected, entry.ID)
+			}
+		}
+		if _, err := it.Next(context.Background()); !errors.Is(err, ErrIteratorDone) {
+			t.Errorf("expected ErrIteratorDone, got %v", err)
+		}
+	})
+
+	t.Run("follow", func(t *testing.T) {
+		it := guild.IterateAuditLogs(&IterateAuditLogs{
+			Follow:       true,
+			After:        2,
+			PageSize:     2,
+			PollInterval: time.Millisecond,
+		})
+		for expected := Snowflake(3); expected <= 5; expected++ {
+			entry, err := it.Next(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if entry.ID != expected {
+				t.Errorf("expected entry %d, got %d", expected, entry.ID)
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		if entry, err := it.Next(ctx); err == nil {
+			t.Errorf("expected polling to stop when the context expires, got entry %d", entry.ID)
+		}
+	})
+}
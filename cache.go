@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/andersfylling/disgord/json"
 )
@@ -41,6 +42,7 @@ func NewBasicCache() *BasicCache {
 	cache.Channels.Store = make(map[Snowflake]*Channel)
 	cache.Guilds.Store = make(map[Snowflake]*guildCacheContainer)
 	cache.VoiceStates.Store = make(map[Snowflake]*voiceStateCacheEntry)
+	cache.Permissions.Store = make(map[Snowflake]map[permissionsCacheKey]permissionsCacheEntry)
 
 	return cache
 }
@@ -92,6 +94,81 @@ func (g *guildsCache) RemoveChannelID(guildID, channelID Snowflake) {
 	}
 }
 
+type permissionsCacheKey struct {
+	channelID Snowflake
+	userID    Snowflake
+}
+
+type permissionsCacheEntry struct {
+	permissions PermissionBit
+	expires     time.Time // a timeout ends, zero when the entry stays valid until invalidated
+}
+
+// permissionsCache holds computed member permissions per guild until a role, channel or member changes.
+// Invalidation must happen after the cached data was updated, and bumps the generation of the guild so
+// computations that started before the update are not stored.
+type permissionsCache struct {
+	sync.Mutex
+	Store       map[Snowflake]map[permissionsCacheKey]permissionsCacheEntry
+	generations map[Snowflake]uint64
+}
+
+func (p *permissionsCache) get(guildID Snowflake, key permissionsCacheKey, now time.Time) (PermissionBit, uint64, bool) {
+	p.Lock()
+	defer p.Unlock()
+
+	generation := p.generations[guildID]
+	entry, ok := p.Store[guildID][key]
+	if !ok || (!entry.expires.IsZero() && !now.Before(entry.expires)) {
+		return 0, generation, false
+	}
+	return entry.permissions, generation, true
+}
+
+func (p *permissionsCache) set(guildID Snowflake, key permissionsCacheKey, entry permissionsCacheEntry, generation uint64) {
+	p.Lock()
+	defer p.Unlock()
+
+	if p.generations[guildID] != generation {
+		return
+	}
+	if p.Store == nil {
+		p.Store = make(map[Snowflake]map[permissionsCacheKey]permissionsCacheEntry)
+	}
+	if _, ok := p.Store[guildID]; !ok {
+		p.Store[guildID] = make(map[permissionsCacheKey]permissionsCacheEntry)
+	}
+	p.Store[guildID][key] = entry
+}
+
+// InvalidateGuild drops every computed permission in the guild
+func (p *permissionsCache) InvalidateGuild(guildID Snowflake) {
+	p.Lock()
+	defer p.Unlock()
+	p.bump(guildID)
+	delete(p.Store, guildID)
+}
+
+func (p *permissionsCache) bump(guildID Snowflake) {
+	if p.generations == nil {
+		p.generations = make(map[Snowflake]uint64)
+	}
+	p.generations[guildID]++
+}
+
+// InvalidateMember drops the computed permissions of a member
+func (p *permissionsCache) InvalidateMember(guildID, userID Snowflake) {
+	p.Lock()
+	defer p.Unlock()
+	p.bump(guildID)
+
+	for key := range p.Store[guildID] {
+		if key.userID == userID {
+			delete(p.Store[guildID], key)
+		}
+	}
+}
+
 type usersCache struct {
 	sync.Mutex
 	Store map[Snowflake]*User
@@ -200,6 +277,7 @@ type BasicCache struct {
 	VoiceStates voiceStateCache
 	Channels    channelsCache
 	Guilds      guildsCache
+	Permissions permissionsCache
 }
 
 var _ Cache = (*BasicCache)(nil)
@@ -318,6 +396,7 @@ func (c *BasicCache) ChannelUpdate(data []byte) (*ChannelUpdate, error) {
 	channelID := metadata.ID
 
 	c.Guilds.AddChannelID(metadata.GuildID, channelID)
+	defer c.Permissions.InvalidateGuild(metadata.GuildID)
 
 	c.Channels.Lock()
 	defer c.Channels.Unlock()
@@ -353,6 +432,7 @@ func (c *BasicCache) ChannelDelete(data []byte) (*ChannelDelete, error) {
 		return nil, err
 	}
 	c.Patch(cd)
+	defer c.Permissions.InvalidateGuild(cd.Channel.GuildID)
 
 	wg := &sync.WaitGroup{}
 	wg.Add(1)
@@ -457,6 +537,7 @@ func (c *BasicCache) GuildMembersChunk(data []byte) (evt *GuildMembersChunk, err
 	if evt, err = c.CacheNop.GuildMembersChunk(data); err != nil {
 		return nil, err
 	}
+	defer c.Permissions.InvalidateGuild(evt.GuildID)
 
 	users := make([]*User, 0, len(evt.Members))
 	for i := range evt.Members {
@@ -497,6 +578,7 @@ func (c *BasicCache) GuildMemberRemove(data []byte) (*GuildMemberRemove, error)
 	if err != nil {
 		return nil, err
 	}
+	defer c.Permissions.InvalidateMember(gmr.GuildID, gmr.User.ID)
 
 	c.Guilds.Lock()
 	defer c.Guilds.Unlock()
@@ -515,6 +597,7 @@ func (c *BasicCache) GuildMemberUpdate(data []byte) (evt *GuildMemberUpdate, err
 	if evt, err = c.CacheNop.GuildMemberUpdate(data); err != nil {
 		return nil, err
 	}
+	defer c.Permissions.InvalidateMember(evt.GuildID, evt.User.ID)
 
 	c.Guilds.Lock()
 	defer c.Guilds.Unlock()
@@ -540,6 +623,7 @@ func (c *BasicCache) GuildMemberAdd(data []byte) (*GuildMemberAdd, error) {
 	if err != nil {
 		return nil, err
 	}
+	defer c.Permissions.InvalidateMember(evt.Member.GuildID, evt.Member.UserID)
 
 	// save user
 	wg := sync.WaitGroup{}
@@ -603,6 +687,7 @@ func (c *BasicCache) GuildCreate(data []byte) (*GuildCreate, error) {
 	if err != nil {
 		return nil, err
 	}
+	defer c.Permissions.InvalidateGuild(evt.Guild.ID)
 
 	guild := DeepCopy(evt.Guild).(*Guild)
 	_, channelIDs, membersMap := c.deconstructGuild(guild)
@@ -624,6 +709,7 @@ func (c *BasicCache) GuildUpdate(data []byte) (*GuildUpdate, error) {
 	if err != nil {
 		return nil, err
 	}
+	defer c.Permissions.InvalidateGuild(evt.Guild.ID)
 
 	c.Guilds.Lock()
 	defer c.Guilds.Unlock()
@@ -659,6 +745,7 @@ func (c *BasicCache) GuildDelete(data []byte) (*GuildDelete, error) {
 		return nil, err
 	}
 	c.Patch(guildEvt)
+	defer c.Permissions.InvalidateGuild(guildEvt.UnavailableGuild.ID)
 
 	c.Guilds.Lock()
 	defer c.Guilds.Unlock()
@@ -673,6 +760,7 @@ func (c *BasicCache) GuildRoleCreate(data []byte) (evt *GuildRoleCreate, err err
 	if evt, err = c.CacheNop.GuildRoleCreate(data); err != nil {
 		return nil, err
 	}
+	defer c.Permissions.InvalidateGuild(evt.GuildID)
 	role := DeepCopy(evt.Role).(*Role)
 
 	c.Guilds.Lock()
@@ -704,6 +792,7 @@ func (c *BasicCache) GuildRoleUpdate(data []byte) (evt *GuildRoleUpdate, err err
 	if evt, err = c.CacheNop.GuildRoleUpdate(data); err != nil {
 		return nil, err
 	}
+	defer c.Permissions.InvalidateGuild(evt.GuildID)
 
 	c.Guilds.Lock()
 	defer c.Guilds.Unlock()
@@ -739,6 +828,7 @@ func (c *BasicCache) GuildRoleDelete(data []byte) (evt *GuildRoleDelete, err err
 	if evt, err = c.CacheNop.GuildRoleDelete(data); err != nil {
 		return nil, err
 	}
+	defer c.Permissions.InvalidateGuild(evt.GuildID)
 
 	c.Guilds.Lock()
 	defer c.Guilds.Unlock()
@@ -909,3 +999,57 @@ func (c *BasicCache) GetUser(id Snowflake) (*User, error) {
 	}
 	return nil, ErrCacheMiss
 }
+
+// GetMemberPermissions computes the permissions of a member in a channel using ComputePermissions. Use a zero
+// channel ID for the guild wide permissions. The result is cached until a role, channel or the member is updated.
+func (c *BasicCache) GetMemberPermissions(guildID, channelID, userID Snowflake) (PermissionBit, error) {
+	now := time.Now()
+	key := permissionsCacheKey{channelID: channelID, userID: userID}
+	permissions, generation, ok := c.Permissions.get(guildID, key, now)
+	if ok {
+		return permissions, nil
+	}
+
+	guild := &Guild{ID: guildID}
+	var channel *Channel
+	if !channelID.IsZero() {
+		c.Channels.Lock()
+		if stored, ok := c.Channels.Store[channelID]; ok {
+			channel = DeepCopy(stored).(*Channel)
+			if parent, ok := c.Channels.Store[channel.ParentID]; ok && isThread(channel.Type) {
+				guild.Channels = []*Channel{DeepCopy(parent).(*Channel)}
+			}
+		}
+		c.Channels.Unlock()
+
+		if channel == nil || channel.GuildID != guildID {
+			return 0, ErrCacheMiss
+		}
+	}
+
+	c.Guilds.Lock()
+	defer c.Guilds.Unlock()
+
+	container, ok := c.Guilds.Store[guildID]
+	if !ok {
+		return 0, ErrCacheMiss
+	}
+	member, ok := container.Members[userID]
+	if !ok || member == nil {
+		return 0, ErrCacheMiss
+	}
+
+	// roles are only read while holding the guild lock, so they don't need to be copied
+	guild.OwnerID = container.Guild.OwnerID
+	guild.Roles = container.Guild.Roles
+
+	entry := permissionsCacheEntry{
+		permissions: computePermissions(guild, member, channel, now),
+	}
+	if isTimedOut(member, now) {
+		entry.expires = member.CommunicationDisabledUntil.Time
+	}
+	c.Permissions.set(guildID, key, entry, generation)
+
+	return entry.permissions, nil
+}
@@ -37,7 +37,7 @@ type CacheGetter interface {
 	//GetGuildBans(id Snowflake) ([]*Ban, error)
 	//GetGuildBan(guildID, userID Snowflake) (*Ban, error)
 	GetGuildRoles(guildID Snowflake) ([]*Role, error)
-	//GetMemberPermissions(guildID, userID Snowflake) (permissions PermissionBit, err error)
+	GetMemberPermissions(guildID, channelID, userID Snowflake) (PermissionBit, error)
 	//GetGuildVoiceRegions(id Snowflake) ([]*VoiceRegion, error)
 	//GetGuildInvites(id Snowflake) ([]*Invite, error)
 	//GetGuildIntegrations(id Snowflake) ([]*Integration, error)
@@ -61,6 +61,7 @@ type CacheUpdater interface {
 	ChannelDelete(data []byte) (*ChannelDelete, error)
 	ChannelPinsUpdate(data []byte) (*ChannelPinsUpdate, error)
 	ChannelUpdate(data []byte) (*ChannelUpdate, error)
+	GuildAuditLogEntryCreate(data []byte) (*GuildAuditLogEntryCreate, error)
 	GuildBanAdd(data []byte) (*GuildBanAdd, error)
 	GuildBanRemove(data []byte) (*GuildBanRemove, error)
 	GuildCreate(data []byte) (*GuildCreate, error)
@@ -118,6 +119,8 @@ func cacheDispatcher(c Cache, event string, data []byte) (evt EventType, err err
 		evt, err = c.ChannelPinsUpdate(data)
 	case EvtChannelUpdate:
 		evt, err = c.ChannelUpdate(data)
+	case EvtGuildAuditLogEntryCreate:
+		evt, err = c.GuildAuditLogEntryCreate(data)
 	case EvtGuildBanAdd:
 		evt, err = c.GuildBanAdd(data)
 	case EvtGuildBanRemove:
@@ -259,6 +262,13 @@ func (c *CacheNop) ChannelUpdate(data []byte) (evt *ChannelUpdate, err error) {
 	c.Patch(evt)
 	return evt, nil
 }
+func (c *CacheNop) GuildAuditLogEntryCreate(data []byte) (evt *GuildAuditLogEntryCreate, err error) {
+	if err = json.Unmarshal(data, &evt); err != nil {
+		return nil, err
+	}
+	c.Patch(evt)
+	return evt, nil
+}
 func (c *CacheNop) GuildBanAdd(data []byte) (evt *GuildBanAdd, err error) {
 	if err = json.Unmarshal(data, &evt); err != nil {
 		return nil, err
@@ -599,3 +609,6 @@ func (c *CacheNop) GetMessages(channel Snowflake, p *GetMessages) ([]*Message, e
 func (c *CacheNop) GetMembers(guildID Snowflake, p *GetMembers) ([]*Member, error) {
 	return nil, ErrCacheMiss
 }
+func (c *CacheNop) GetMemberPermissions(guildID, channelID, userID Snowflake) (PermissionBit, error) {
+	return 0, ErrCacheMiss
+}
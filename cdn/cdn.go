@@ -0,0 +1,229 @@
+// Package cdn builds URLs for the assets Discord hosts on its CDN, such as avatars, guild icons and stickers.
+//
+// Every asset type only supports a subset of image formats. Requesting a format that is not supported,
+// a GIF for a hash that is not animated or a size that is not a power of two between 16 and 4096,
+// results in an error instead of a URL that Discord would reject.
+//
+// https://discord.com/developers/docs/reference#image-formatting
+package cdn
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BaseURL is the root of every asset URL.
+const BaseURL = "https://cdn.discordapp.com"
+
+const (
+	// MinSize is the smallest image size the CDN accepts.
+	MinSize = 16
+	// MaxSize is the largest image size the CDN accepts.
+	MaxSize = 4096
+)
+
+// animatedHashPrefix is prepended to hashes of animated assets.
+const animatedHashPrefix = "a_"
+
+var (
+	ErrInvalidSize       = errors.New("image size can be any power of two between 16 and 4096")
+	ErrUnsupportedFormat = errors.New("format is not supported for this asset")
+	ErrNotAnimated       = errors.New("gif can only be used for animated assets")
+	ErrMissingHash       = errors.New("asset hash is empty")
+)
+
+// Format is an image, or animation, format supported by the CDN.
+type Format string
+
+const (
+	PNG    Format = "png"
+	JPEG   Format = "jpg"
+	WebP   Format = "webp"
+	GIF    Format = "gif"
+	Lottie Format = "json"
+)
+
+var (
+	formatsStatic   = []Format{PNG, JPEG, WebP}
+	formatsAnimated = []Format{PNG, JPEG, WebP, GIF}
+	formatsSticker  = []Format{PNG, GIF, Lottie}
+	formatsDefault  = []Format{PNG}
+)
+
+// IsAnimated reports whether the hash references an animated asset.
+func IsAnimated(hash string) bool {
+	return strings.HasPrefix(hash, animatedHashPrefix)
+}
+
+// ValidSize reports whether the size is accepted by the CDN. A size of 0 means that no size is requested.
+func ValidSize(size int) bool {
+	if size == 0 {
+		return true
+	}
+	return MinSize <= size && size <= MaxSize && size&(size-1) == 0
+}
+
+// Asset is a single file hosted on the Discord CDN.
+type Asset struct {
+	// Path is the location of the asset without a file extension, eg. /icons/{guild.id}/{guild.icon}.
+	Path string
+
+	// Animated signifies that the asset can be served as a GIF.
+	Animated bool
+
+	// Formats lists the formats that can be used for this asset type.
+	Formats []Format
+}
+
+// Supports reports whether the asset can be served in the given format.
+func (a *Asset) Supports(format Format) bool {
+	return contains(a.Formats, format)
+}
+
+// URL creates a link to the asset in the given format. Use a size of 0 to let Discord pick the default size.
+func (a *Asset) URL(format Format, size int) (string, error) {
+	if a.Path == "" {
+		return "", ErrMissingHash
+	}
+	if !a.Supports(format) {
+		return "", fmt.Errorf("%s: %w", format, ErrUnsupportedFormat)
+	}
+	if format == GIF && !a.Animated {
+		return "", ErrNotAnimated
+	}
+	if !ValidSize(size) {
+		return "", ErrInvalidSize
+	}
+
+	url := BaseURL + a.Path + "." + string(format)
+	if size > 0 && format != Lottie {
+		url += "?size=" + strconv.Itoa(size)
+	}
+	return url, nil
+}
+
+// PreferredURL picks GIF for animated assets when preferGIF is true. Otherwise WebP is used when supported,
+// and the first supported format as a last resort.
+func (a *Asset) PreferredURL(size int, preferGIF bool) (string, error) {
+	if preferGIF && a.Animated && a.Supports(GIF) {
+		return a.URL(GIF, size)
+	}
+	if a.Supports(WebP) {
+		return a.URL(WebP, size)
+	}
+	if len(a.Formats) == 0 {
+		return "", ErrUnsupportedFormat
+	}
+	return a.URL(a.Formats[0], size)
+}
+
+func hashed(path, hash string, formats []Format) *Asset {
+	if hash == "" {
+		return &Asset{Formats: formats}
+	}
+	animated := IsAnimated(hash)
+	if animated && !contains(formats, GIF) {
+		animated = false
+	}
+	return &Asset{
+		Path:     path + "/" + hash,
+		Animated: animated,
+		Formats:  formats,
+	}
+}
+
+func contains(formats []Format, format Format) bool {
+	for i := range formats {
+		if formats[i] == format {
+			return true
+		}
+	}
+	return false
+}
+
+// CustomEmoji /emojis/{emoji.id}
+func CustomEmoji(emojiID fmt.Stringer, animated bool) *Asset {
+	return &Asset{
+		Path:     "/emojis/" + emojiID.String(),
+		Animated: animated,
+		Formats:  formatsAnimated,
+	}
+}
+
+// GuildIcon /icons/{guild.id}/{guild.icon}
+func GuildIcon(guildID fmt.Stringer, hash string) *Asset {
+	return hashed("/icons/"+guildID.String(), hash, formatsAnimated)
+}
+
+// GuildSplash /splashes/{guild.id}/{guild.splash}
+func GuildSplash(guildID fmt.Stringer, hash string) *Asset {
+	return hashed("/splashes/"+guildID.String(), hash, formatsStatic)
+}
+
+// GuildDiscoverySplash /discovery-splashes/{guild.id}/{guild.discovery_splash}
+func GuildDiscoverySplash(guildID fmt.Stringer, hash string) *Asset {
+	return hashed("/discovery-splashes/"+guildID.String(), hash, formatsStatic)
+}
+
+// GuildBanner /banners/{guild.id}/{guild.banner}
+func GuildBanner(guildID fmt.Stringer, hash string) *Asset {
+	return hashed("/banners/"+guildID.String(), hash, formatsAnimated)
+}
+
+// UserBanner /banners/{user.id}/{user.banner}
+func UserBanner(userID fmt.Stringer, hash string) *Asset {
+	return hashed("/banners/"+userID.String(), hash, formatsAnimated)
+}
+
+// DefaultUserAvatar /embed/avatars/{index}. The index is the discriminator modulo 5.
+func DefaultUserAvatar(index int) *Asset {
+	return &Asset{
+		Path:    "/embed/avatars/" + strconv.Itoa(index%5),
+		Formats: formatsDefault,
+	}
+}
+
+// UserAvatar /avatars/{user.id}/{user.avatar}
+func UserAvatar(userID fmt.Stringer, hash string) *Asset {
+	return hashed("/avatars/"+userID.String(), hash, formatsAnimated)
+}
+
+// GuildMemberAvatar /guilds/{guild.id}/users/{user.id}/avatars/{member.avatar}
+func GuildMemberAvatar(guildID, userID fmt.Stringer, hash string) *Asset {
+	return hashed("/guilds/"+guildID.String()+"/users/"+userID.String()+"/avatars", hash, formatsAnimated)
+}
+
+// ApplicationIcon /app-icons/{application.id}/{application.icon}
+func ApplicationIcon(applicationID fmt.Stringer, hash string) *Asset {
+	return hashed("/app-icons/"+applicationID.String(), hash, formatsStatic)
+}
+
+// ApplicationCover /app-icons/{application.id}/{application.cover_image}
+func ApplicationCover(applicationID fmt.Stringer, hash string) *Asset {
+	return hashed("/app-icons/"+applicationID.String(), hash, formatsStatic)
+}
+
+// Sticker /stickers/{sticker.id}. A sticker is only served in the format it was uploaded with: PNG for both
+// PNG and APNG stickers, GIF or Lottie. Lottie stickers can not be resized.
+func Sticker(stickerID fmt.Stringer, format Format) *Asset {
+	asset := &Asset{
+		Path:     "/stickers/" + stickerID.String(),
+		Animated: format == GIF,
+	}
+	if contains(formatsSticker, format) {
+		asset.Formats = []Format{format}
+	}
+	return asset
+}
+
+// RoleIcon /role-icons/{role.id}/{role.icon}
+func RoleIcon(roleID fmt.Stringer, hash string) *Asset {
+	return hashed("/role-icons/"+roleID.String(), hash, formatsStatic)
+}
+
+// GuildScheduledEventCover /guild-events/{scheduled_event.id}/{scheduled_event.image}
+func GuildScheduledEventCover(eventID fmt.Stringer, hash string) *Asset {
+	return hashed("/guild-events/"+eventID.String(), hash, formatsStatic)
+}
@@ -0,0 +1,93 @@
+//go:build !integration
+// +build !integration
+
+package cdn
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+type id uint64
+
+func (i id) String() string {
+	return strconv.FormatUint(uint64(i), 10)
+}
+
+func TestValidSize(t *testing.T) {
+	valid := []int{0, 16, 32, 64, 128, 256, 512, 1024, 2048, 4096}
+	for _, size := range valid {
+		if !ValidSize(size) {
+			t.Errorf("expected size %d to be valid", size)
+		}
+	}
+
+	invalid := []int{-16, 1, 8, 15, 17, 100, 8192}
+	for _, size := range invalid {
+		if ValidSize(size) {
+			t.Errorf("expected size %d to be invalid", size)
+		}
+	}
+}
+
+func TestAsset_URL(t *testing.T) {
+	testCases := []struct {
+		name   string
+		asset  *Asset
+		format Format
+		size   int
+		url    string
+		err    error
+	}{
+		{"guild icon", GuildIcon(id(1), "abc"), PNG, 64, BaseURL + "/icons/1/abc.png?size=64", nil},
+		{"guild icon no size", GuildIcon(id(1), "abc"), WebP, 0, BaseURL + "/icons/1/abc.webp", nil},
+		{"animated guild icon", GuildIcon(id(1), "a_abc"), GIF, 4096, BaseURL + "/icons/1/a_abc.gif?size=4096", nil},
+		{"static gif", GuildIcon(id(1), "abc"), GIF, 64, "", ErrNotAnimated},
+		{"invalid size", GuildIcon(id(1), "abc"), PNG, 100, "", ErrInvalidSize},
+		{"missing hash", GuildIcon(id(1), ""), PNG, 64, "", ErrMissingHash},
+		{"splash gif", GuildSplash(id(1), "a_abc"), GIF, 64, "", ErrUnsupportedFormat},
+		{"member avatar", GuildMemberAvatar(id(1), id(2), "abc"), JPEG, 16, BaseURL + "/guilds/1/users/2/avatars/abc.jpg?size=16", nil},
+		{"default avatar", DefaultUserAvatar(7), PNG, 0, BaseURL + "/embed/avatars/2.png", nil},
+		{"default avatar webp", DefaultUserAvatar(7), WebP, 0, "", ErrUnsupportedFormat},
+		{"lottie sticker", Sticker(id(3), Lottie), Lottie, 512, BaseURL + "/stickers/3.json", nil},
+		{"lottie sticker png", Sticker(id(3), Lottie), PNG, 512, "", ErrUnsupportedFormat},
+		{"emoji", CustomEmoji(id(4), true), GIF, 32, BaseURL + "/emojis/4.gif?size=32", nil},
+		{"role icon", RoleIcon(id(5), "abc"), WebP, 32, BaseURL + "/role-icons/5/abc.webp?size=32", nil},
+		{"event cover", GuildScheduledEventCover(id(6), "abc"), PNG, 0, BaseURL + "/guild-events/6/abc.png", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			url, err := tc.asset.URL(tc.format, tc.size)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+			if url != tc.url {
+				t.Errorf("expected url %q, got %q", tc.url, url)
+			}
+		})
+	}
+}
+
+func TestAsset_PreferredURL(t *testing.T) {
+	url, err := UserAvatar(id(1), "a_abc").PreferredURL(128, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := BaseURL + "/avatars/1/a_abc.gif?size=128"; url != expected {
+		t.Errorf("expected %q, got %q", expected, url)
+	}
+
+	url, err = UserAvatar(id(1), "a_abc").PreferredURL(128, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := BaseURL + "/avatars/1/a_abc.webp?size=128"; url != expected {
+		t.Errorf("expected %q, got %q", expected, url)
+	}
+
+	if _, err = Sticker(id(1), JPEG).PreferredURL(0, false); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("expected unsupported format error, got %v", err)
+	}
+}
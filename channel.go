@@ -8,9 +8,11 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/andersfylling/disgord/internal/endpoint"
 	"github.com/andersfylling/disgord/internal/httd"
@@ -157,7 +159,8 @@ func (c *Channel) valid() bool {
 	return true
 }
 
-// GetPermissions is used to get a members permissions in a channel.
+// GetPermissions is used to get a members permissions in a channel. Overwrites are applied in the order
+// they are stored, see ComputePermissions or Cache.GetMemberPermissions for Discord's complete algorithm.
 func (c *Channel) GetPermissions(ctx context.Context, s GuildQueryBuilderCaller, member *Member) (permissions PermissionBit, err error) {
 	// Get the guild permissions.
 	permissions, err = member.GetPermissions(ctx, s)
@@ -318,6 +321,11 @@ type ChannelQueryBuilder interface {
 	// will only be counted once.
 	DeleteMessages(params *DeleteMessages) error
 
+	// PurgeMessages Deletes the messages matching the filter, starting from the newest message. Recent messages are
+	// deleted in bulk, while messages older than 14 days are deleted one by one. Requires the 'MANAGE_MESSAGES' and
+	// 'READ_MESSAGE_HISTORY' permissions.
+	PurgeMessages(params *PurgeMessages) (*PurgeResult, error)
+
 	// GetMessages Returns the messages for a channel. If operating on a guild channel, this endpoint requires
 	// the 'VIEW_CHANNEL' permission to be present on the current user. If the current user is missing
 	// the 'READ_MESSAGE_HISTORY' permission in the channel then this will return no messages
@@ -382,6 +390,18 @@ type ChannelQueryBuilder interface {
 	// GetJoinedPrivateArchivedThreads Returns archived threads in the channel that are of type GUILD_PRIVATE_THREAD, and the user has joined.
 	// Threads are ordered by their id, in descending order. Requires the READ_MESSAGE_HISTORY permission.
 	GetJoinedPrivateArchivedThreads(params *GetArchivedThreads) (*ArchivedThreads, error)
+
+	// IteratePublicArchivedThreads walks the public archived threads, from the most recently archived.
+	// Only PaginateBackward is supported, and the cursor is a archive timestamp. See SnowflakeFromTime.
+	IteratePublicArchivedThreads(params *IterateArchivedThreads) *ThreadIterator
+
+	// IteratePrivateArchivedThreads walks the private archived threads, from the most recently archived.
+	// Only PaginateBackward is supported, and the cursor is a archive timestamp. See SnowflakeFromTime.
+	IteratePrivateArchivedThreads(params *IterateArchivedThreads) *ThreadIterator
+
+	// IterateJoinedPrivateArchivedThreads walks the joined private archived threads, from the highest thread ID.
+	// Only PaginateBackward is supported.
+	IterateJoinedPrivateArchivedThreads(params *IterateArchivedThreads) *ThreadIterator
 }
 
 type channelQueryBuilder struct {
@@ -443,6 +463,18 @@ func (c channelQueryBuilder) Get() (*Channel, error) {
 	return getChannel(r.Execute)
 }
 
+// managePermission returns the permission required to update or delete the channel. Thread owners may
+// edit their own threads, so threads are left for Discord to verify.
+func (c channelQueryBuilder) managePermission() PermissionBit {
+	if !c.client.config.PreflightPermissionChecks {
+		return 0
+	}
+	if channel, err := c.client.cache.GetChannel(c.cid); err == nil && isThread(channel.Type) {
+		return 0
+	}
+	return PermissionManageChannels
+}
+
 // Update [REST] Update a channel's settings. Returns a channel on success, and a 400 BAD REQUEST
 // on invalid parameters. All JSON parameters are optional.
 //
@@ -458,6 +490,10 @@ func (c channelQueryBuilder) Update(params *UpdateChannel) (*Channel, error) {
 		return nil, err
 	}
 
+	if err := c.client.preflight(0, c.cid, c.managePermission()); err != nil {
+		return nil, err
+	}
+
 	r := c.client.newRESTRequest(&httd.Request{
 		Method:      http.MethodPatch,
 		Ctx:         c.ctx,
@@ -509,6 +545,10 @@ func (c channelQueryBuilder) Delete() (channel *Channel, err error) {
 		return nil, ErrMissingChannelID
 	}
 
+	if err = c.client.preflight(0, c.cid, c.managePermission()); err != nil {
+		return nil, err
+	}
+
 	r := c.client.newRESTRequest(&httd.Request{
 		Method:   http.MethodDelete,
 		Endpoint: endpoint.Channel(c.cid),
@@ -566,6 +606,10 @@ func (c channelQueryBuilder) UpdatePermissions(overwriteID Snowflake, params *Up
 		return ErrMissingPermissionOverwriteID
 	}
 
+	if err = c.client.preflight(0, c.cid, PermissionManageRoles); err != nil {
+		return err
+	}
+
 	r := c.client.newRESTRequest(&httd.Request{
 		Method:      http.MethodPut,
 		Ctx:         c.ctx,
@@ -609,6 +653,10 @@ func (c channelQueryBuilder) CreateInvite(params *CreateInvite) (*Invite, error)
 		return nil, ErrMissingRESTParams
 	}
 
+	if err := c.client.preflight(0, c.cid, PermissionCreateInstantInvite); err != nil {
+		return nil, err
+	}
+
 	r := c.client.newRESTRequest(&httd.Request{
 		Method:      http.MethodPost,
 		Ctx:         c.ctx,
@@ -653,6 +701,10 @@ func (c channelQueryBuilder) DeletePermission(overwriteID Snowflake) (err error)
 		return ErrMissingPermissionOverwriteID
 	}
 
+	if err = c.client.preflight(0, c.cid, PermissionManageRoles); err != nil {
+		return err
+	}
+
 	r := c.client.newRESTRequest(&httd.Request{
 		Method:   http.MethodDelete,
 		Endpoint: endpoint.ChannelPermission(c.cid, overwriteID),
@@ -975,6 +1027,10 @@ func (c channelQueryBuilder) DeleteMessages(params *DeleteMessages) (err error)
 		return err
 	}
 
+	if err = c.client.preflight(0, c.cid, PermissionManageMessages); err != nil {
+		return err
+	}
+
 	r := c.client.newRESTRequest(&httd.Request{
 		Method:      http.MethodPost,
 		Ctx:         c.ctx,
@@ -987,6 +1043,167 @@ func (c channelQueryBuilder) DeleteMessages(params *DeleteMessages) (err error)
 	return err
 }
 
+// bulkDeleteMaxAge is the age limit of messages given to DeleteMessages. A minute is subtracted to
+// make up for clock differences and the time spent waiting for the rate limiter.
+const bulkDeleteMaxAge = 14*24*time.Hour - time.Minute
+
+// PurgeMessages filters the messages to delete with ChannelQueryBuilder.PurgeMessages.
+// A message must match every filter that is set.
+type PurgeMessages struct {
+	// Before and After limit the purge to messages between the two IDs. Both are exclusive.
+	Before Snowflake
+	After  Snowflake
+
+	AuthorID Snowflake
+	Content  func(content string) bool
+
+	// Match decides whether a message that passed the other filters is deleted, such as to only delete
+	// messages without attachments:
+	//
+	//	Match: func(msg *disgord.Message) bool { return len(msg.Attachments) == 0 }
+	Match func(msg *Message) bool
+
+	// IncludePinned allows pinned messages to be deleted. They are skipped by default. Combine it with Match to
+	// only delete pinned messages.
+	IncludePinned bool
+
+	// Limit is the maximum number of messages to delete. Zero purges every matching message until After
+	// is reached, or the start of the channel when After is not set.
+	Limit int
+
+	// Progress is called after every delete request. The result must not be modified.
+	Progress func(result *PurgeResult)
+}
+
+func (p *PurgeMessages) match(msg *Message) bool {
+	if msg.Pinned && !p.IncludePinned {
+		return false
+	}
+	if !p.AuthorID.IsZero() && (msg.Author == nil || msg.Author.ID != p.AuthorID) {
+		return false
+	}
+	if p.Content != nil && !p.Content(msg.Content) {
+		return false
+	}
+	return p.Match == nil || p.Match(msg)
+}
+
+// PurgeFailure is a message that could not be deleted.
+type PurgeFailure struct {
+	MessageID Snowflake
+	Err       error
+}
+
+// PurgeResult reports the progress of ChannelQueryBuilder.PurgeMessages.
+type PurgeResult struct {
+	Scanned int
+	Deleted []Snowflake
+	Failed  []PurgeFailure
+}
+
+func (r *PurgeResult) deleted(ids []Snowflake, err error) {
+	if err == nil {
+		r.Deleted = append(r.Deleted, ids...)
+		return
+	}
+	for _, id := range ids {
+		r.Failed = append(r.Failed, PurgeFailure{MessageID: id, Err: err})
+	}
+}
+
+// PurgeMessages [REST] Deletes the messages matching the filter, starting from the newest message. Messages
+// younger than 14 days are deleted with DeleteMessages in batches of up to 100, older messages are deleted one by one.
+// When a delete request fails the purge continues, and ErrPurgeIncomplete is returned together with the failed
+// message IDs in PurgeResult.Failed. Other errors, such as a cancelled context, stops the purge.
+func (c channelQueryBuilder) PurgeMessages(params *PurgeMessages) (result *PurgeResult, err error) {
+	if c.cid.IsZero() {
+		return nil, ErrMissingChannelID
+	}
+	if params == nil {
+		params = &PurgeMessages{}
+	}
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	result = &PurgeResult{}
+	deleteMessages := func(ids []Snowflake) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		switch len(ids) {
+		case 0:
+			return nil
+		case 1:
+			result.deleted(ids, c.Message(ids[0]).WithContext(ctx).WithFlags(c.flags).Delete())
+		default:
+			result.deleted(ids, c.DeleteMessages(&DeleteMessages{Messages: ids}))
+		}
+		if params.Progress != nil {
+			params.Progress(result)
+		}
+		return nil
+	}
+
+	const pageSize = 100
+	remaining := params.Limit
+	cursor := params.Before
+	for {
+		msgs, err := c.GetMessages(&GetMessages{Before: cursor, Limit: pageSize})
+		if err != nil {
+			return result, err
+		}
+		sort.Slice(msgs, func(i, j int) bool {
+			return msgs[i].ID > msgs[j].ID
+		})
+
+		var bulk, single []Snowflake
+		bulkCutoff := SnowflakeFromTime(time.Now().Add(-bulkDeleteMaxAge))
+		reachedEnd := len(msgs) < pageSize
+		for _, msg := range msgs {
+			if !params.After.IsZero() && msg.ID <= params.After {
+				reachedEnd = true
+				break
+			}
+			result.Scanned++
+			if !params.match(msg) {
+				continue
+			}
+			if msg.ID > bulkCutoff {
+				bulk = append(bulk, msg.ID)
+			} else {
+				single = append(single, msg.ID)
+			}
+			if remaining--; remaining == 0 {
+				reachedEnd = true
+				break
+			}
+		}
+		if len(msgs) > 0 {
+			cursor = msgs[len(msgs)-1].ID
+		}
+
+		if err := deleteMessages(bulk); err != nil {
+			return result, err
+		}
+		for _, id := range single {
+			if err := deleteMessages([]Snowflake{id}); err != nil {
+				return result, err
+			}
+		}
+
+		if reachedEnd {
+			break
+		}
+	}
+
+	if len(result.Failed) > 0 {
+		return result, fmt.Errorf("%d of %d: %w", len(result.Failed), len(result.Failed)+len(result.Deleted), ErrPurgeIncomplete)
+	}
+	return result, nil
+}
+
 // AllowedMentions allows finer control over mentions in a message, see
 // https://discord.com/developers/docs/resources/channel#allowed-mentions-object for more info.
 // Any strings in the Parse value must be any from ["everyone", "users", "roles"].
@@ -1048,11 +1265,23 @@ type CreateMessage struct {
 	Embed *Embed `json:"embed,omitempty"`
 }
 
+// requiredPermissions returns the permissions needed to send the message.
+func (p *CreateMessage) requiredPermissions() PermissionBit {
+	required := PermissionSendMessages
+	if len(p.Files) > 0 {
+		required |= PermissionAttachFiles
+	}
+	return required
+}
+
 func (p *CreateMessage) prepare() (postBody interface{}, contentType string, err error) {
 	// spoiler tag
 	if p.SpoilerTagContent && len(p.Content) > 0 {
 		p.Content = "|| " + p.Content + " ||"
 	}
+	if err = p.FindErrors(); err != nil {
+		return nil, "", err
+	}
 
 	if len(p.Files) == 0 {
 		postBody = p
@@ -1137,6 +1366,10 @@ func (c channelQueryBuilder) CreateMessage(params *CreateMessage) (ret *Message,
 		return nil, err
 	}
 
+	if err = c.client.preflight(0, c.cid, params.requiredPermissions()); err != nil {
+		return nil, err
+	}
+
 	r := c.client.newRESTRequest(&httd.Request{
 		Method:      http.MethodPost,
 		Ctx:         c.ctx,
@@ -1208,6 +1441,10 @@ func (c channelQueryBuilder) CreateWebhook(params *CreateWebhook) (ret *Webhook,
 		return nil, err
 	}
 
+	if err = c.client.preflight(0, c.cid, PermissionManageWebhooks); err != nil {
+		return nil, err
+	}
+
 	r := c.client.newRESTRequest(&httd.Request{
 		Method:      http.MethodPost,
 		Ctx:         c.ctx,
@@ -1384,6 +1621,29 @@ var _ URLQueryStringer = (*GetArchivedThreads)(nil)
 
 // GetPublicArchivedThreads https://discord.com/developers/docs/resources/channel#list-public-archived-threads
 func (c channelQueryBuilder) GetPublicArchivedThreads(params *GetArchivedThreads) (*ArchivedThreads, error) {
+	if params == nil {
+		return c.getArchivedThreads(endpoint.ChannelThreadsArchivedPublic(c.cid), nil)
+	}
+	return c.getArchivedThreads(endpoint.ChannelThreadsArchivedPublic(c.cid), params)
+}
+
+// GetPrivateArchivedThreads https://discord.com/developers/docs/resources/channel#list-private-archived-threads
+func (c channelQueryBuilder) GetPrivateArchivedThreads(params *GetArchivedThreads) (*ArchivedThreads, error) {
+	if params == nil {
+		return c.getArchivedThreads(endpoint.ChannelThreadsArchivedPrivate(c.cid), nil)
+	}
+	return c.getArchivedThreads(endpoint.ChannelThreadsArchivedPrivate(c.cid), params)
+}
+
+// GetJoinedPrivateArchivedThreads https://discord.com/developers/docs/resources/channel#list-joined-private-archived-threads
+func (c channelQueryBuilder) GetJoinedPrivateArchivedThreads(params *GetArchivedThreads) (*ArchivedThreads, error) {
+	if params == nil {
+		return c.getArchivedThreads(endpoint.ChannelThreadsCurrentUserArchivedPrivate(c.cid), nil)
+	}
+	return c.getArchivedThreads(endpoint.ChannelThreadsCurrentUserArchivedPrivate(c.cid), params)
+}
+
+func (c channelQueryBuilder) getArchivedThreads(e string, params URLQueryStringer) (*ArchivedThreads, error) {
 	var query string
 	if params != nil {
 		query += params.URLQueryString()
@@ -1392,7 +1652,7 @@ func (c channelQueryBuilder) GetPublicArchivedThreads(params *GetArchivedThreads
 	r := c.client.newRESTRequest(&httd.Request{
 		Method:      http.MethodGet,
 		Ctx:         c.ctx,
-		Endpoint:    endpoint.ChannelThreadsArchivedPublic(c.cid) + query,
+		Endpoint:    e + query,
 		ContentType: httd.ContentTypeJSON,
 	}, c.flags)
 	r.factory = func() interface{} {
@@ -1405,48 +1665,208 @@ func (c channelQueryBuilder) GetPublicArchivedThreads(params *GetArchivedThreads
 	return getArchivedThreads(r.Execute)
 }
 
-// GetPrivateArchivedThreads https://discord.com/developers/docs/resources/channel#list-private-archived-threads
-func (c channelQueryBuilder) GetPrivateArchivedThreads(params *GetArchivedThreads) (*ArchivedThreads, error) {
-	var query string
-	if params != nil {
-		query += params.URLQueryString()
+// IterateArchivedThreads configures a ThreadIterator.
+type IterateArchivedThreads struct {
+	Pagination
+
+	// Until stops the iteration when it returns true. The thread is not returned.
+	Until func(thread *Channel) bool
+}
+
+// ThreadIterator walks a list of archived threads. See ChannelQueryBuilder.IteratePublicArchivedThreads.
+type ThreadIterator struct {
+	it interface {
+		next(ctx context.Context) (interface{}, error)
 	}
+}
 
-	r := c.client.newRESTRequest(&httd.Request{
-		Method:      http.MethodGet,
-		Ctx:         c.ctx,
-		Endpoint:    endpoint.ChannelThreadsArchivedPrivate(c.cid) + query,
-		ContentType: httd.ContentTypeJSON,
-	}, c.flags)
-	r.factory = func() interface{} {
-		return &ArchivedThreads{
-			Threads: make([]*Channel, 0),
-			Members: make([]*ThreadMember, 0),
+// Next returns the next thread, or ErrIteratorDone after the last thread.
+func (t *ThreadIterator) Next(ctx context.Context) (*Channel, error) {
+	item, err := t.it.next(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return item.(*Channel), nil
+}
+
+// archivedThreadPager walks archived threads from the most recently archived, using the archive timestamp
+// of the last thread as the "before" cursor. Several threads can be archived at the same timestamp, so the
+// threads at the cursor are remembered and skipped if Discord returns them again.
+type archivedThreadPager struct {
+	fetch    func(ctx context.Context, before time.Time, limit int) (*ArchivedThreads, error)
+	until    func(thread *Channel) bool
+	pageSize int
+
+	before   time.Time
+	boundary map[Snowflake]bool // threads archived at the before timestamp
+
+	err    error
+	done   bool
+	buffer []*Channel
+}
+
+func (p *archivedThreadPager) next(ctx context.Context) (interface{}, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	for len(p.buffer) == 0 {
+		if p.done {
+			return nil, ErrIteratorDone
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if err := p.nextPage(ctx); err != nil {
+			return nil, err
 		}
 	}
 
-	return getArchivedThreads(r.Execute)
+	thread := p.buffer[0]
+	p.buffer = p.buffer[1:]
+	if p.until != nil && p.until(thread) {
+		p.done = true
+		p.buffer = nil
+		return nil, ErrIteratorDone
+	}
+	return thread, nil
 }
 
-// GetJoinedPrivateArchivedThreads https://discord.com/developers/docs/resources/channel#list-joined-private-archived-threads
-func (c channelQueryBuilder) GetJoinedPrivateArchivedThreads(params *GetArchivedThreads) (*ArchivedThreads, error) {
-	var query string
-	if params != nil {
-		query += params.URLQueryString()
+func (p *archivedThreadPager) nextPage(ctx context.Context) error {
+	page, err := p.fetch(ctx, p.before, p.pageSize)
+	if err != nil {
+		return err
 	}
 
-	r := c.client.newRESTRequest(&httd.Request{
-		Method:      http.MethodGet,
-		Ctx:         c.ctx,
-		Endpoint:    endpoint.ChannelThreadsCurrentUserArchivedPrivate(c.cid) + query,
-		ContentType: httd.ContentTypeJSON,
-	}, c.flags)
-	r.factory = func() interface{} {
-		return &ArchivedThreads{
-			Threads: make([]*Channel, 0),
-			Members: make([]*ThreadMember, 0),
+	threads := make([]*Channel, 0, len(page.Threads))
+	for _, thread := range page.Threads {
+		if thread != nil {
+			threads = append(threads, thread)
 		}
 	}
+	archivedAt := func(thread *Channel) time.Time {
+		return thread.ThreadMetadata.ArchiveTimestamp.Time
+	}
+	sort.SliceStable(threads, func(i, j int) bool {
+		return archivedAt(threads[i]).After(archivedAt(threads[j]))
+	})
 
-	return getArchivedThreads(r.Execute)
+	fresh := make([]*Channel, 0, len(threads))
+	for _, thread := range threads {
+		if !p.boundary[thread.ID] {
+			fresh = append(fresh, thread)
+		}
+	}
+	if len(threads) > 0 {
+		last := archivedAt(threads[len(threads)-1])
+		if !last.Equal(p.before) {
+			p.boundary = make(map[Snowflake]bool)
+		}
+		p.before = last
+		for _, thread := range threads {
+			if archivedAt(thread).Equal(last) {
+				p.boundary[thread.ID] = true
+			}
+		}
+	}
+
+	if len(fresh) == 0 && len(threads) > 0 && page.HasMore {
+		// the page only held threads archived at the cursor, which would be returned again and again
+		p.before = p.before.Add(-time.Microsecond)
+		p.boundary = nil
+		return nil
+	}
+	p.done = !page.HasMore
+	p.buffer = fresh
+	return nil
+}
+
+// iterateArchivedThreadsByTime creates a thread iterator for the endpoints that paginate by archive timestamp.
+// A cursor given in the pagination is read as a timestamp, see SnowflakeFromTime.
+func (c channelQueryBuilder) iterateArchivedThreadsByTime(e string, params *IterateArchivedThreads) *ThreadIterator {
+	const pageSizeMax = 100
+	if params == nil {
+		params = &IterateArchivedThreads{}
+	}
+
+	p := &archivedThreadPager{
+		fetch: func(ctx context.Context, before time.Time, limit int) (*ArchivedThreads, error) {
+			query := urlQuery{"limit": limit}
+			if !before.IsZero() {
+				query["before"] = Time{before.UTC()}
+			}
+
+			q := c
+			q.ctx = ctx
+			return q.getArchivedThreads(e, query)
+		},
+		until:    params.Until,
+		pageSize: params.PageSize,
+	}
+	if p.pageSize <= 0 || p.pageSize > pageSizeMax {
+		p.pageSize = pageSizeMax
+	}
+	if !params.Cursor.IsZero() {
+		p.before = params.Cursor.Date()
+	}
+	if params.Direction == PaginateForward {
+		p.err = ErrUnsupportedPaginationDirection
+	}
+	return &ThreadIterator{it: p}
+}
+
+// iterateArchivedThreads creates a thread iterator for the endpoints that paginate by thread ID.
+func (c channelQueryBuilder) iterateArchivedThreads(e string, params *IterateArchivedThreads) *ThreadIterator {
+	const pageSizeMax = 100
+	if params == nil {
+		params = &IterateArchivedThreads{}
+	}
+
+	fetch := func(ctx context.Context, before, _ Snowflake, limit int) ([]interface{}, error) {
+		query := urlQuery{"limit": limit}
+		if !before.IsZero() {
+			query["before"] = before
+		}
+
+		q := c
+		q.ctx = ctx
+		threads, err := q.getArchivedThreads(e, query)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, len(threads.Threads))
+		for i := range threads.Threads {
+			items[i] = threads.Threads[i]
+		}
+		return items, nil
+	}
+	id := func(item interface{}) Snowflake {
+		return item.(*Channel).ID
+	}
+
+	it := newCursorIterator(params.Pagination, PaginateBackward, pageSizeMax, fetch, id)
+	if params.Until != nil {
+		until := params.Until
+		it.until = func(item interface{}) bool {
+			return until(item.(*Channel))
+		}
+	}
+	return &ThreadIterator{it: it.only(PaginateBackward)}
+}
+
+// IteratePublicArchivedThreads creates a iterator for the public archived threads. Requires the READ_MESSAGE_HISTORY permission.
+func (c channelQueryBuilder) IteratePublicArchivedThreads(params *IterateArchivedThreads) *ThreadIterator {
+	return c.iterateArchivedThreadsByTime(endpoint.ChannelThreadsArchivedPublic(c.cid), params)
+}
+
+// IteratePrivateArchivedThreads creates a iterator for the private archived threads. Requires both the
+// READ_MESSAGE_HISTORY and MANAGE_THREADS permissions.
+func (c channelQueryBuilder) IteratePrivateArchivedThreads(params *IterateArchivedThreads) *ThreadIterator {
+	return c.iterateArchivedThreadsByTime(endpoint.ChannelThreadsArchivedPrivate(c.cid), params)
+}
+
+// IterateJoinedPrivateArchivedThreads creates a iterator for the private archived threads the current user
+// has joined. Requires the READ_MESSAGE_HISTORY permission.
+func (c channelQueryBuilder) IterateJoinedPrivateArchivedThreads(params *IterateArchivedThreads) *ThreadIterator {
+	return c.iterateArchivedThreads(endpoint.ChannelThreadsCurrentUserArchivedPrivate(c.cid), params)
 }
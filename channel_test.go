@@ -4,7 +4,14 @@
 package disgord
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/andersfylling/disgord/json"
 )
@@ -52,3 +59,124 @@ func TestChannel_JSONIconNull(t *testing.T) {
 		t.Error(c.Icon, "was not empty")
 	}
 }
+
+func TestChannelQueryBuilder_PurgeMessages(t *testing.T) {
+	now := time.Now()
+	recent := func(minutes int) Snowflake {
+		return SnowflakeFromTime(now.Add(-time.Duration(minutes) * time.Minute))
+	}
+	old := func(days int) Snowflake {
+		return SnowflakeFromTime(now.Add(-time.Duration(days) * 24 * time.Hour))
+	}
+
+	const author Snowflake = 7
+	messages := []*Message{
+		{ID: recent(1), Author: &User{ID: author}, Content: "spam"},
+		{ID: recent(2), Author: &User{ID: author}, Content: "spam", Pinned: true},
+		{ID: recent(3), Author: &User{ID: 8}, Content: "spam"},
+		{ID: recent(4), Author: &User{ID: author}, Content: "hello"},
+		{ID: recent(5), Author: &User{ID: author}, Content: "spam"},
+		{ID: old(20), Author: &User{ID: author}, Content: "spam"},
+		{ID: old(30), Author: &User{ID: author}, Content: "spam"},
+	}
+	failing := old(30)
+
+	var mu sync.Mutex
+	var bulk [][]Snowflake
+	var single []Snowflake
+	doer := doerMock(func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		path := req.URL.Path
+		switch {
+		case req.Method == http.MethodGet && strings.HasSuffix(path, "/channels/1/messages"):
+			before, _ := strconv.ParseUint(req.URL.Query().Get("before"), 10, 64)
+			page := make([]*Message, 0)
+			for _, msg := range messages {
+				if before == 0 || uint64(msg.ID) < before {
+					page = append(page, msg)
+				}
+			}
+			return jsonResponse(req, http.StatusOK, page)
+		case req.Method == http.MethodPost && strings.HasSuffix(path, "/bulk-delete"):
+			var body DeleteMessages
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+			bulk = append(bulk, body.Messages)
+			return jsonResponse(req, http.StatusNoContent, nil)
+		case req.Method == http.MethodDelete:
+			id := ParseSnowflakeString(path[strings.LastIndex(path, "/")+1:])
+			if id == failing {
+				return jsonResponse(req, http.StatusNotFound, map[string]interface{}{"code": 10008, "message": "Unknown Message"})
+			}
+			single = append(single, id)
+			return jsonResponse(req, http.StatusNoContent, nil)
+		}
+		t.Errorf("unexpected request %s %s", req.Method, path)
+		return jsonResponse(req, http.StatusNotFound, nil)
+	})
+
+	var progress int
+	result, err := newMockedClient(t, doer).Channel(1).WithContext(context.Background()).PurgeMessages(&PurgeMessages{
+		AuthorID: author,
+		Content: func(content string) bool {
+			return content == "spam"
+		},
+		Progress: func(_ *PurgeResult) {
+			progress++
+		},
+	})
+	if !errors.Is(err, ErrPurgeIncomplete) {
+		t.Fatalf("expected ErrPurgeIncomplete, got %v", err)
+	}
+
+	if len(bulk) != 1 || len(bulk[0]) != 2 || bulk[0][0] != recent(1) || bulk[0][1] != recent(5) {
+		t.Errorf("expected one bulk delete of the two recent matches, got %v", bulk)
+	}
+	if len(single) != 1 || single[0] != old(20) {
+		t.Errorf("expected the old message to be deleted on its own, got %v", single)
+	}
+	if len(result.Failed) != 1 || result.Failed[0].MessageID != failing {
+		t.Errorf("expected one failure, got %+v", result.Failed)
+	}
+	if len(result.Deleted) != 3 {
+		t.Errorf("expected 3 deleted messages, got %d", len(result.Deleted))
+	}
+	if result.Scanned != len(messages) {
+		t.Errorf("expected %d scanned messages, got %d", len(messages), result.Scanned)
+	}
+	if progress != 3 {
+		t.Errorf("expected 3 progress updates, got %d", progress)
+	}
+}
+
+func TestPurgeMessages_match(t *testing.T) {
+	withAttachment := &Message{Content: "spam", Attachments: []*Attachment{{ID: 1}}}
+	withoutAttachment := &Message{Content: "spam"}
+	pinned := &Message{Content: "spam", Pinned: true}
+
+	testCases := []struct {
+		name     string
+		params   *PurgeMessages
+		expected []bool
+	}{
+		{"no filters", &PurgeMessages{}, []bool{true, true, false}},
+		{"without attachments", &PurgeMessages{Match: func(msg *Message) bool {
+			return len(msg.Attachments) == 0
+		}}, []bool{false, true, false}},
+		{"only pinned", &PurgeMessages{IncludePinned: true, Match: func(msg *Message) bool {
+			return msg.Pinned
+		}}, []bool{false, false, true}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for i, msg := range []*Message{withAttachment, withoutAttachment, pinned} {
+				if matched := tc.params.match(msg); matched != tc.expected[i] {
+					t.Errorf("message %d: expected %t, got %t", i, tc.expected[i], matched)
+				}
+			}
+		})
+	}
+}
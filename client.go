@@ -136,15 +136,42 @@ func createClient(ctx context.Context, conf *Config) (c *Client, err error) {
 		conf.Intents |= conf.DMIntents
 	}
 
+	restBaseURL := conf.RESTBaseURL
+	var restProxySecret string
+	if conf.RESTProxyURL != "" {
+		restBaseURL = conf.RESTProxyURL
+		restProxySecret = conf.RESTProxySecret
+		if conf.RESTBucketManager == nil {
+			conf.RESTBucketManager = httd.NewPassthroughManager()
+		}
+	}
+
+	invalidRequestPolicy := RESTInvalidRequestPolicy{WarnAt: []int{1000, 5000, 9000}}
+	if conf.RESTInvalidRequestPolicy != nil {
+		invalidRequestPolicy = *conf.RESTInvalidRequestPolicy
+	}
+	if invalidRequestPolicy.Warn == nil {
+		log := conf.Logger
+		invalidRequestPolicy.Warn = func(invalidRequests int) {
+			log.Error("sent ", invalidRequests, " invalid REST requests within ", httd.InvalidRequestWindow,
+				", Discord bans the IP address at ", httd.InvalidRequestLimit)
+		}
+	}
+
 	httdClient, err := httd.NewClient(&httd.Config{
 		APIVersion:                   constant.DiscordVersion,
 		BotToken:                     conf.BotToken,
 		UserAgentSourceURL:           constant.GitHubURL,
 		UserAgentVersion:             constant.Version,
 		UserAgentExtra:               conf.ProjectName,
-		HttpClient:                   conf.HTTPClient,
+		HttpClient:                   conf.HttpClient,
 		CancelRequestWhenRateLimited: conf.CancelRequestWhenRateLimited,
+		BaseURL:                      restBaseURL,
+		ProxySecret:                  restProxySecret,
 		RESTBucketManager:            conf.RESTBucketManager,
+		RetryPolicy:                  conf.RESTRetryPolicy,
+		Interceptors:                 conf.RESTInterceptors,
+		InvalidRequestPolicy:         &invalidRequestPolicy,
 	})
 	if err != nil {
 		return nil, err
@@ -190,6 +217,12 @@ func createClient(ctx context.Context, conf *Config) (c *Client, err error) {
 		pool:                newPools(),
 		eventChan:           evtChan,
 	}
+	if conf.CoalesceRESTRequests {
+		c.coalescer = newRESTCoalescer()
+	}
+	if len(conf.RESTCacheTTLs) > 0 {
+		c.restCache = newRESTCache(conf.RESTCacheTTLs)
+	}
 	c.handlers.c = c // parent reference
 	c.dispatcher.addSessionInstance(c)
 	c.clientQueryBuilder.client = c
@@ -229,14 +262,16 @@ type Config struct {
 	BotToken string
 
 	// HttpClient allows for different wrappers or alternative http logic as long as they have the same
-	// .Do(..).. method as the http.Client.
+	// .Do(..).. method as the http.Client. Every REST request is sent with it. When nil, the deprecated
+	// HTTPClient is used, which defaults to DefaultHttpClient.
 	// Note that rate limiting is not done in the roundtripper layer at this point, so anything with re-tries, logic
 	// that triggers a new http request without going through Disgord interface, will not be rate limited and this
 	// can cause you to get banned in the long term. Be careful.
 	HttpClient          HttpClientDoer
 	WebsocketHttpClient *http.Client // no way around this, sadly. At least for now.
 
-	// Deprecated: use WebsocketHttpClient and HttpClient
+	// Deprecated: use WebsocketHttpClient and HttpClient. REST requests are only sent with it when
+	// HttpClient is nil.
 	HTTPClient *http.Client
 
 	// Deprecated: use WebsocketHttpClient and HttpClient
@@ -256,11 +291,55 @@ type Config struct {
 
 	CancelRequestWhenRateLimited bool
 
+	// RESTRetryPolicy sends failed REST requests again, such as those that received a 503 response or
+	// had their connection reset. Nil disables retries, see DefaultRESTRetryPolicy.
+	RESTRetryPolicy *RESTRetryPolicy
+
+	// RESTInvalidRequestPolicy decides what happens as invalid REST requests are counted, which are responses with
+	// the status code 401, 403 or 429. Discord bans the IP address after 10,000 invalid requests within 10 minutes.
+	// By default a warning is logged at 1,000, 5,000 and 9,000 invalid requests, and the circuit breaker is disabled.
+	// Requests always fail fast with a *ErrRESTCircuitOpen while the IP address is banned by Cloudflare.
+	RESTInvalidRequestPolicy *RESTInvalidRequestPolicy
+
+	// CoalesceRESTRequests lets concurrent identical GET requests share one REST call, such as when many
+	// handlers fetch the same uncached member at once. Every caller receives its own copy of the result.
+	CoalesceRESTRequests bool
+
+	// RESTCacheTTLs caches the REST responses of resources the Cache does not hold, such as webhooks and invites,
+	// for as long as the time to live of the resource. The resource of an endpoint is the last segment of its path
+	// that is given here, such that "webhooks" caches both /channels/{channel.id}/webhooks and /webhooks/{webhook.id}.
+	// Cached responses are removed once a mutating request or a gateway event touches the resource, and the
+	// IgnoreCache flag makes a request fetch a fresh response. Nil disables the cache, see DefaultRESTCacheTTLs.
+	RESTCacheTTLs map[string]time.Duration
+
+	// RESTBaseURL replaces the Discord API URL, "https://discord.com/api", such as for the fake Discord server of
+	// the disgordtest package. Unlike RESTProxyURL, rate limiting is still handled by this client. The gateway URL
+	// is given by the API, or can be set in ShardConfig.URL.
+	RESTBaseURL string
+
+	// RESTProxyURL sends every REST request to a disgord REST proxy (see cmd/rest-proxy) instead of Discord,
+	// such as "http://127.0.0.1:8080/api". The proxy handles the rate limits for every process using it,
+	// so rate limiting is disabled in this client unless a RESTBucketManager is given.
+	RESTProxyURL string
+
+	// RESTProxySecret is the shared secret of the REST proxy, set by DISGORD_PROXY_SECRET when starting it.
+	RESTProxySecret string
+
+	// RESTInterceptors wrap every REST request sent to Discord, such as for tracing, metrics or
+	// auditing. They are called in the given order, see RESTInterceptor.
+	RESTInterceptors []RESTInterceptor
+
 	// LoadMembersQuietly will start fetching members for all Guilds in the background.
 	// There is currently no proper way to detect when the loading is done nor if it
 	// finished successfully.
 	LoadMembersQuietly bool
 
+	// PreflightPermissionChecks makes REST methods that require permissions, such as banning a member or deleting
+	// messages, verify that the bot has them before sending the request. A *ErrMissingPermissions is returned instead
+	// of a request Discord would deny. The permissions are computed from the cache, so requests are sent as usual
+	// when the guild, channel or bot member is not cached.
+	PreflightPermissionChecks bool
+
 	// Presence will automatically be emitted to discord on start up
 	Presence *UpdateStatusPayload
 
@@ -280,7 +359,10 @@ type Config struct {
 	// ## You use these features on your own risk.
 	// ##
 	// ################################################
-	RESTBucketManager httd.RESTBucketManager
+
+	// RESTBucketManager keeps track of the REST rate limits. Use NewRESTBucketStoreManager to share the
+	// rate limits between clients, such as processes using the same bot token.
+	RESTBucketManager RESTBucketManager
 
 	DisableCache bool
 	Cache        Cache
@@ -326,6 +408,12 @@ type Client struct {
 	// req holds the rate limiting logic and error parsing unique for Discord
 	req *httd.Client
 
+	// coalescer shares identical GET requests in flight, nil when disabled
+	coalescer *restCoalescer
+
+	// restCache holds REST responses for the resources the Cache does not hold, nil when disabled
+	restCache *restCache
+
 	WebsocketHttpClient *http.Client
 
 	shardManager gateway.ShardManager
@@ -417,6 +505,38 @@ func (c *Client) RESTRatelimitBuckets() (group map[string][]string) {
 	return c.req.BucketGrouping()
 }
 
+// InvalidRESTRequests returns the number of invalid REST requests within the last 10 minutes, see
+// Config.RESTInvalidRequestPolicy.
+func (c *Client) InvalidRESTRequests() int {
+	return c.req.InvalidRequests()
+}
+
+// ExportRESTBuckets returns the REST rate limit buckets discovered so far as JSON, including which hashed
+// endpoints belong to which bucket. Load them with ImportRESTBuckets after a restart, to avoid running into
+// rate limits while the buckets are discovered again.
+func (c *Client) ExportRESTBuckets() ([]byte, error) {
+	return c.req.ExportBuckets()
+}
+
+// ImportRESTBuckets loads the REST rate limit buckets created by ExportRESTBuckets, and should be called
+// before any requests are sent. Buckets discovered more than maxAge ago are skipped, unless maxAge is 0.
+func (c *Client) ImportRESTBuckets(data []byte, maxAge time.Duration) error {
+	return c.req.ImportBuckets(data, maxAge)
+}
+
+// RESTRatelimitSnapshot describes the state of every REST rate limit bucket, such as the remaining requests,
+// when the bucket resets and how many requests are waiting for it.
+func (c *Client) RESTRatelimitSnapshot() (*RESTBucketsSnapshot, error) {
+	return c.req.BucketsSnapshot()
+}
+
+// EstimateRESTWait predicts how long a request to the endpoint, such as "/channels/123/messages", must wait
+// for the rate limits if it was sent now. It can be used to let users know when to try again, or to pick
+// another approach.
+func (c *Client) EstimateRESTWait(method, endpoint string) (time.Duration, error) {
+	return c.req.EstimateWait(method, endpoint)
+}
+
 // Cache returns the cacheLink manager for the session
 func (c *Client) Cache() Cache {
 	return c.cache
@@ -537,6 +657,7 @@ func (c *Client) EditInteractionResponse(ctx context.Context, interaction *Inter
 		Body:        postBody,
 		Ctx:         ctx,
 		ContentType: contentType,
+		Priority:    httd.PriorityHigh,
 	}
 	_, _, err = c.req.Do(ctx, req)
 	return err
@@ -560,6 +681,7 @@ func (c *Client) SendInteractionResponse(ctx context.Context, interaction *Inter
 		Body:        postBody,
 		Ctx:         ctx,
 		ContentType: contentType,
+		Priority:    httd.PriorityHigh,
 	}
 	_, _, err = c.req.Do(ctx, req)
 	return err
@@ -598,6 +720,7 @@ func (c *Client) newRESTRequest(conf *httd.Request, flags Flag) *rest {
 	}
 	r.init()
 	r.flags = flags
+	conf.Priority = flags.priority(conf.Priority)
 
 	return r
 }
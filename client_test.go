@@ -6,6 +6,7 @@ package disgord
 import (
 	"context"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"path/filepath"
 	"sort"
@@ -462,3 +463,35 @@ func TestInternalStateHandlers(t *testing.T) {
 		t.Errorf("Removing a connected guild should affect the internal state. Got %d, wants %d", len(c.GetConnectedGuilds()), 0)
 	}
 }
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestConfig_HttpClient(t *testing.T) {
+	var sent bool
+	client, err := NewClient(context.Background(), Config{
+		BotToken:     "testing",
+		DisableCache: true,
+		HTTPClient: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			t.Error("expected the deprecated HTTPClient to not be used for REST requests")
+			return jsonResponse(req, http.StatusOK, &User{ID: 1})
+		})},
+		HttpClient: doerMock(func(req *http.Request) (*http.Response, error) {
+			sent = true
+			return jsonResponse(req, http.StatusOK, &User{ID: 1})
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = client.User(1).Get(); err != nil {
+		t.Fatal(err)
+	}
+	if !sent {
+		t.Error("expected the request to be sent with HttpClient")
+	}
+}
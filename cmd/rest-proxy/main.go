@@ -0,0 +1,65 @@
+// Command rest-proxy serves the Discord REST API locally and forwards every request through one set of rate limit
+// buckets, such that several processes using the same bot token don't exceed the rate limits together. The bot
+// token is read from the DISGORD_TOKEN environment variable, and is set on every request by the proxy.
+//
+// Anyone able to reach the proxy can act as the bot, so it only listens on the loopback interface by default.
+// Set DISGORD_PROXY_SECRET to require a shared secret from every request, which is needed to listen on any other
+// address. Point the processes at the proxy with disgord.Config.RESTProxyURL and RESTProxySecret:
+//
+//	DISGORD_PROXY_SECRET=... rest-proxy -addr 127.0.0.1:8080
+//
+//	client := disgord.New(disgord.Config{
+//		BotToken:        os.Getenv("DISGORD_TOKEN"),
+//		RESTProxyURL:    "http://127.0.0.1:8080/api",
+//		RESTProxySecret: os.Getenv("DISGORD_PROXY_SECRET"),
+//	})
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/andersfylling/disgord/internal/constant"
+	"github.com/andersfylling/disgord/internal/httd"
+)
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:8080", "address to listen on")
+	flag.Parse()
+
+	secret := os.Getenv("DISGORD_PROXY_SECRET")
+	if secret == "" && !isLoopback(*addr) {
+		log.Fatalf("refusing to listen on %s without DISGORD_PROXY_SECRET, as anyone reaching it can act as the bot", *addr)
+	}
+
+	client, err := httd.NewClient(&httd.Config{
+		APIVersion:         constant.DiscordVersion,
+		BotToken:           os.Getenv("DISGORD_TOKEN"),
+		HttpClient:         &http.Client{},
+		UserAgentSourceURL: constant.GitHubURL,
+		UserAgentVersion:   constant.Version,
+		UserAgentExtra:     "rest-proxy",
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("forwarding Discord REST requests from %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, httd.NewProxy(client, secret)))
+}
+
+// isLoopback reports whether the address only accepts connections from the local machine.
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -196,6 +196,12 @@ func ParseSnowflakeString(v string) Snowflake {
 	return util.ParseSnowflakeString(v)
 }
 
+// SnowflakeFromTime creates the lowest possible snowflake for the given timestamp. It can be used as a
+// before or after parameter when paginating the Discord API by time.
+func SnowflakeFromTime(t time.Time) Snowflake {
+	return util.SnowflakeFromTime(t)
+}
+
 func newErrorMissingSnowflake(message string) *ErrorMissingSnowflake {
 	return &ErrorMissingSnowflake{
 		info: message,
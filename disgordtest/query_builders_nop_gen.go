@@ -136,6 +136,18 @@ func (c *ChannelQueryBuilderNop) GetWebhooks() ([]*disgord.Webhook, error) {
 	return nil, nil
 }
 
+func (c *ChannelQueryBuilderNop) IterateJoinedPrivateArchivedThreads(_ *disgord.IterateArchivedThreads) *disgord.ThreadIterator {
+	return nil
+}
+
+func (c *ChannelQueryBuilderNop) IteratePrivateArchivedThreads(_ *disgord.IterateArchivedThreads) *disgord.ThreadIterator {
+	return nil
+}
+
+func (c *ChannelQueryBuilderNop) IteratePublicArchivedThreads(_ *disgord.IterateArchivedThreads) *disgord.ThreadIterator {
+	return nil
+}
+
 func (c *ChannelQueryBuilderNop) JoinThread() error {
 	return nil
 }
@@ -152,6 +164,10 @@ func (c *ChannelQueryBuilderNop) Message(_ disgord.Snowflake) disgord.MessageQue
 	return nil
 }
 
+func (c *ChannelQueryBuilderNop) PurgeMessages(_ *disgord.PurgeMessages) (*disgord.PurgeResult, error) {
+	return nil, nil
+}
+
 func (c *ChannelQueryBuilderNop) RemoveThreadMember(_ disgord.Snowflake) error {
 	return nil
 }
@@ -268,6 +284,10 @@ func (c *CurrentUserQueryBuilderNop) GetGuilds(_ *disgord.GetCurrentUserGuilds)
 	return nil, nil
 }
 
+func (c *CurrentUserQueryBuilderNop) IterateGuilds(_ *disgord.IterateCurrentUserGuilds) *disgord.GuildIterator {
+	return nil
+}
+
 func (c *CurrentUserQueryBuilderNop) Update(_ *disgord.UpdateUser) (*disgord.User, error) {
 	return nil, nil
 }
@@ -351,6 +371,14 @@ func (g *GatewayQueryBuilderNop) GetBot() (*gateway.GatewayBot, error) {
 	return nil, nil
 }
 
+func (g *GatewayQueryBuilderNop) GuildAuditLogEntryCreate(_ func(disgord.Session, *disgord.GuildAuditLogEntryCreate), _ ...func(disgord.Session, *disgord.GuildAuditLogEntryCreate)) {
+	return
+}
+
+func (g *GatewayQueryBuilderNop) GuildAuditLogEntryCreateChan(_ chan *disgord.GuildAuditLogEntryCreate, _ ...chan *disgord.GuildAuditLogEntryCreate) {
+	return
+}
+
 func (g *GatewayQueryBuilderNop) GuildBanAdd(_ func(disgord.Session, *disgord.GuildBanAdd), _ ...func(disgord.Session, *disgord.GuildBanAdd)) {
 	return
 }
@@ -931,6 +959,14 @@ func (g *GuildQueryBuilderNop) GetWidget() (*disgord.GuildWidget, error) {
 	return nil, nil
 }
 
+func (g *GuildQueryBuilderNop) IterateAuditLogs(_ *disgord.IterateAuditLogs) *disgord.AuditLogIterator {
+	return nil
+}
+
+func (g *GuildQueryBuilderNop) IterateBans(_ *disgord.IterateBans) *disgord.BanIterator {
+	return nil
+}
+
 func (g *GuildQueryBuilderNop) Leave() error {
 	return nil
 }
@@ -1051,6 +1087,10 @@ func (g *GuildScheduledEventQueryBuilderNop) GetMembers(_ *disgord.GetScheduledE
 	return nil, nil
 }
 
+func (g *GuildScheduledEventQueryBuilderNop) IterateMembers(_ *disgord.IterateScheduledEventMembers) *disgord.ScheduledEventMemberIterator {
+	return nil
+}
+
 func (g *GuildScheduledEventQueryBuilderNop) Update(_ *disgord.UpdateScheduledEvent) (*disgord.GuildScheduledEvent, error) {
 	return nil, nil
 }
@@ -1175,6 +1215,10 @@ func (r *ReactionQueryBuilderNop) Get(_ disgord.URLQueryStringer) ([]*disgord.Us
 	return nil, nil
 }
 
+func (r *ReactionQueryBuilderNop) Iterate(_ *disgord.IterateReactions) *disgord.UserIterator {
+	return nil
+}
+
 type UserQueryBuilderNop struct {
 	Ctx       context.Context
 	Flags     disgord.Flag
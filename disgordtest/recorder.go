@@ -0,0 +1,330 @@
+package disgordutil
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/andersfylling/disgord"
+	"github.com/andersfylling/disgord/json"
+)
+
+// Redacted replaces the bot token, and the webhook and interaction tokens, in recorded cassettes.
+const Redacted = "[REDACTED]"
+
+// RecorderMode decides whether a Recorder sends requests to Discord or serves them from the cassette.
+type RecorderMode int
+
+const (
+	// RecorderModeRecord sends every request and writes the exchange to the cassette, replacing any
+	// exchanges recorded earlier.
+	RecorderModeRecord RecorderMode = iota
+
+	// RecorderModeReplay serves every request from the cassette, without network access. Requests that
+	// were not recorded fail with a *ErrUnexpectedRequest.
+	RecorderModeReplay
+)
+
+// Cassette holds the recorded REST exchanges, in the order they were sent.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded REST exchange.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// ErrUnexpectedRequest is returned by a replaying Recorder when the cassette holds no unplayed exchange
+// for the request.
+type ErrUnexpectedRequest struct {
+	Method string
+	URL    string
+}
+
+func (e *ErrUnexpectedRequest) Error() string {
+	return "the cassette has no recorded response for " + e.Method + " " + e.URL
+}
+
+// Recorder wraps the HTTP client of disgord to record REST exchanges to a cassette file, which can later be
+// replayed such that tests run without a bot token or network access. The bot token is redacted from the
+// cassette, as are the webhook and interaction tokens of URL paths such as /webhooks/{webhook.id}/{webhook.token}
+// and /interactions/{interaction.id}/{interaction.token}/callback.
+//
+//	recorder, err := disgordutil.NewRecorder(disgordutil.RecorderModeReplay, "testdata/guild.json", nil)
+//	client := disgord.New(disgord.Config{
+//		BotToken:   "replay",
+//		HttpClient: recorder,
+//	})
+//
+// Requests are replayed in the order they were recorded, matched by the method, redacted URL and JSON body. Note that
+// disgord.New verifies the bot token by fetching the current user, which must be part of the cassette.
+type Recorder struct {
+	mu       sync.Mutex
+	mode     RecorderMode
+	path     string
+	client   disgord.HttpClientDoer
+	cassette *Cassette
+	played   []bool
+}
+
+var _ disgord.HttpClientDoer = (*Recorder)(nil)
+
+// NewRecorder creates a Recorder for the given cassette file. When recording, requests are sent using the
+// given client, or http.DefaultClient if nil. When replaying, the cassette file must exist.
+func NewRecorder(mode RecorderMode, path string, client disgord.HttpClientDoer) (*Recorder, error) {
+	r := &Recorder{
+		mode:     mode,
+		path:     path,
+		client:   client,
+		cassette: &Cassette{},
+	}
+	if r.client == nil {
+		r.client = http.DefaultClient
+	}
+
+	if mode == RecorderModeReplay {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(data, r.cassette); err != nil {
+			return nil, fmt.Errorf("unable to read cassette %s: %w", path, err)
+		}
+		r.played = make([]bool, len(r.cassette.Interactions))
+	}
+	return r, nil
+}
+
+// NewRecorderFromEnv records when the environment variable is set to "record", and replays otherwise.
+// This allows a test to be recorded once with a real bot token, and replayed in CI.
+func NewRecorderFromEnv(env, path string, client disgord.HttpClientDoer) (*Recorder, error) {
+	mode := RecorderModeReplay
+	if os.Getenv(env) == "record" {
+		mode = RecorderModeRecord
+	}
+	return NewRecorder(mode, path, client)
+}
+
+// Mode returns whether the Recorder is recording or replaying.
+func (r *Recorder) Mode() RecorderMode {
+	return r.mode
+}
+
+// Do implements disgord.HttpClientDoer.
+func (r *Recorder) Do(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	if r.mode == RecorderModeReplay {
+		return r.replay(req, body)
+	}
+	return r.record(req, body)
+}
+
+func (r *Recorder) record(req *http.Request, reqBody []byte) (*http.Response, error) {
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	header := resp.Header.Clone()
+	if header.Get("Content-Encoding") == "gzip" {
+		// keep the cassette readable
+		if respBody, err = gunzip(respBody); err != nil {
+			return nil, err
+		}
+		header.Del("Content-Encoding")
+		header.Del("Content-Length")
+	}
+	header.Del("Set-Cookie")
+
+	tokens := secrets(req)
+	interaction := &Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    redact(req.URL.RequestURI(), tokens),
+			Header: redactHeader(req.Header, tokens),
+			Body:   redact(string(reqBody), tokens),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     redactHeader(header, tokens),
+			Body:       redact(string(respBody), tokens),
+		},
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	err = r.save()
+	r.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	return interaction.Response.httpResponse(req), nil
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	tokens := secrets(req)
+	url := redact(req.URL.RequestURI(), tokens)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, interaction := range r.cassette.Interactions {
+		if r.played[i] || !interaction.Request.matches(req.Method, url, redact(string(body), tokens)) {
+			continue
+		}
+		r.played[i] = true
+		return interaction.Response.httpResponse(req), nil
+	}
+	return nil, &ErrUnexpectedRequest{Method: req.Method, URL: url}
+}
+
+// save writes the cassette to the file. The lock must be held.
+func (r *Recorder) save() error {
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, data, 0644)
+}
+
+// Unplayed returns the recorded exchanges that have not been replayed, which tells that the code under test
+// sent fewer requests than when the cassette was recorded.
+func (r *Recorder) Unplayed() []*Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unplayed []*Interaction
+	for i := range r.played {
+		if !r.played[i] {
+			unplayed = append(unplayed, r.cassette.Interactions[i])
+		}
+	}
+	return unplayed
+}
+
+func (r *RecordedRequest) matches(method, url, body string) bool {
+	if r.Method != method || r.URL != url {
+		return false
+	}
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		// multipart bodies use random boundaries
+		return true
+	}
+
+	var recorded, sent interface{}
+	if json.Unmarshal([]byte(r.Body), &recorded) != nil || json.Unmarshal([]byte(body), &sent) != nil {
+		return r.Body == body
+	}
+	return reflect.DeepEqual(recorded, sent)
+}
+
+func (r *RecordedResponse) httpResponse(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Header.Clone(),
+		Body:          ioutil.NopCloser(strings.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+func botToken(req *http.Request) string {
+	auth := req.Header.Get("Authorization")
+	if i := strings.IndexByte(auth, ' '); i >= 0 {
+		return auth[i+1:]
+	}
+	return auth
+}
+
+// pathTokens returns the webhook and interaction tokens of a URL path, which follow the webhook or interaction ID.
+func pathTokens(path string) (tokens []string) {
+	segments := strings.Split(path, "/")
+	for i := 0; i+2 < len(segments); i++ {
+		if (segments[i] == "webhooks" || segments[i] == "interactions") && segments[i+2] != "" {
+			tokens = append(tokens, segments[i+2])
+		}
+	}
+	return tokens
+}
+
+// secrets returns the tokens of the request that must not be written to the cassette.
+func secrets(req *http.Request) []string {
+	tokens := pathTokens(req.URL.Path)
+	if token := botToken(req); token != "" {
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
+
+func redact(s string, tokens []string) string {
+	for _, token := range tokens {
+		s = strings.Replace(s, token, Redacted, -1)
+	}
+	return s
+}
+
+func redactHeader(header http.Header, tokens []string) http.Header {
+	redacted := make(http.Header, len(header))
+	for name, values := range header {
+		for _, value := range values {
+			redacted.Add(name, redact(value, tokens))
+		}
+	}
+	return redacted
+}
+
+func gunzip(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
+
+// IsUnexpectedRequest reports whether the error, such as one returned by a REST method of disgord, was
+// caused by a request the cassette did not hold.
+func IsUnexpectedRequest(err error) bool {
+	var unexpected *ErrUnexpectedRequest
+	return errors.As(err, &unexpected)
+}
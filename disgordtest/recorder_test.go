@@ -0,0 +1,161 @@
+//go:build !integration
+// +build !integration
+
+package disgordutil
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/andersfylling/disgord"
+	"github.com/andersfylling/disgord/json"
+)
+
+const testToken = "NzkyNzE1NDU0MTk2MDg4ODQy.X-hvzA.Ovy4MCQywSkoMRRclStW4xAYK7I"
+
+type doerFunc func(req *http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func gzipResponse(req *http.Request, v interface{}) (*http.Response, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	w := gzip.NewWriter(&b)
+	_, _ = w.Write(data)
+	_ = w.Close()
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set("Content-Encoding", "gzip")
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Header:     header,
+		Body:       ioutil.NopCloser(&b),
+		Request:    req,
+	}, nil
+}
+
+func TestRecorder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "disgord-cassette")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cassette.json")
+
+	flow := func(client *disgord.Client, webhookToken string) (*disgord.User, *disgord.Message, error) {
+		user, err := client.User(1).Get()
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, err = client.Webhook(4).WithToken(webhookToken).Get(); err != nil {
+			return nil, nil, err
+		}
+		msg, err := client.Channel(2).CreateMessage(&disgord.CreateMessage{Content: "hello"})
+		return user, msg, err
+	}
+	newClient := func(token string, doer disgord.HttpClientDoer) *disgord.Client {
+		client, err := disgord.NewClient(context.Background(), disgord.Config{
+			BotToken:     token,
+			DisableCache: true,
+			HttpClient:   doer,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return client
+	}
+
+	var sent int
+	recorder, err := NewRecorder(RecorderModeRecord, path, doerFunc(func(req *http.Request) (*http.Response, error) {
+		sent++
+		switch req.URL.Path {
+		case "/api/v9/channels/2/messages":
+			body, _ := ioutil.ReadAll(req.Body)
+			if !strings.Contains(string(body), "hello") {
+				t.Errorf("expected the request body to be sent, got %s", body)
+			}
+			return gzipResponse(req, &disgord.Message{ID: 3, ChannelID: 2, Content: "hello"})
+		case "/api/v9/webhooks/4/webhook-secret":
+			return gzipResponse(req, &disgord.Webhook{ID: 4, Token: "webhook-secret"})
+		default:
+			return gzipResponse(req, &disgord.User{ID: 1, Username: "test"})
+		}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = flow(newClient(testToken, recorder), "webhook-secret"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), testToken) {
+		t.Error("expected the bot token to be redacted from the cassette")
+	}
+	if strings.Contains(string(data), "webhook-secret") {
+		t.Error("expected the webhook token to be redacted from the cassette")
+	}
+	if !strings.Contains(string(data), "hello") {
+		t.Errorf("expected decompressed bodies in the cassette, got %s", data)
+	}
+
+	replayer, err := NewRecorder(RecorderModeReplay, path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := newClient("replaying", replayer)
+	// replays match on the redacted URL, so the webhook token may differ
+	user, msg, err := flow(client, "replaying")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Username != "test" || msg.Content != "hello" {
+		t.Errorf("unexpected replayed objects %+v, %+v", user, msg)
+	}
+	if sent != 4 {
+		t.Errorf("expected no requests to be sent when replaying, got %d", sent-4)
+	}
+	if unplayed := replayer.Unplayed(); len(unplayed) != 0 {
+		t.Errorf("expected every exchange to be replayed, got %d unplayed", len(unplayed))
+	}
+
+	if _, err = client.Channel(2).CreateMessage(&disgord.CreateMessage{Content: "hello"}); !IsUnexpectedRequest(err) {
+		t.Errorf("expected an unexpected request error, got %v", err)
+	}
+}
+
+func TestRecorder_pathTokens(t *testing.T) {
+	testCases := []struct {
+		path   string
+		tokens []string
+	}{
+		{"/api/v9/webhooks/1/abc", []string{"abc"}},
+		{"/api/v9/webhooks/1/abc/messages/@original", []string{"abc"}},
+		{"/api/v9/interactions/1/abc/callback", []string{"abc"}},
+		{"/api/v9/webhooks/1", nil},
+		{"/api/v9/channels/1/webhooks", nil},
+	}
+	for _, tc := range testCases {
+		if tokens := pathTokens(tc.path); !reflect.DeepEqual(tokens, tc.tokens) {
+			t.Errorf("expected %v for %s, got %v", tc.tokens, tc.path, tokens)
+		}
+	}
+}
@@ -0,0 +1,541 @@
+package disgordutil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andersfylling/disgord"
+	"github.com/andersfylling/disgord/json"
+)
+
+// ServerConfig configures a fake Discord server, see NewServer.
+type ServerConfig struct {
+	// BotToken that clients must authenticate with. Defaults to "fake-token".
+	BotToken string
+
+	// Bot is the user of the bot. Defaults to a user named "fake-bot".
+	Bot *disgord.User
+
+	// Shards is the number of shards recommended by /gateway/bot. Defaults to 1.
+	Shards uint
+
+	// HeartbeatInterval is sent to the clients in the HELLO packet. Defaults to 41.25 seconds, like Discord.
+	HeartbeatInterval time.Duration
+}
+
+// Server is a fake Discord for integration tests, which runs in the test process. It serves a REST API that
+// keeps guilds, channels, messages, members, roles and interactions in memory, and a gateway that handles
+// HELLO, IDENTIFY, READY, heartbeats and resuming. Like Discord, REST requests that change the state dispatch
+// the related events to the connected shards, and test code can dispatch any other event.
+//
+//	server := disgordutil.NewServer(disgordutil.ServerConfig{Shards: 2})
+//	defer server.Close()
+//	guild := server.CreateGuild("test")
+//
+//	client := disgord.New(server.ClientConfig())
+//	err := client.Gateway().Connect()
+//
+// Permissions and intents are not checked, and the REST API is not rate limited.
+type Server struct {
+	conf ServerConfig
+	http *httptest.Server
+
+	mu           sync.Mutex
+	lastID       uint64
+	users        map[disgord.Snowflake]*disgord.User
+	guilds       map[disgord.Snowflake]*disgord.Guild
+	channels     map[disgord.Snowflake]*disgord.Channel
+	messages     map[disgord.Snowflake][]*disgord.Message
+	members      map[disgord.Snowflake]map[disgord.Snowflake]*disgord.Member
+	interactions map[string]*interaction
+
+	gw gatewayState
+}
+
+// interaction is an interaction sent to the bot, with the responses of the bot.
+type interaction struct {
+	evt       *disgord.InteractionCreate
+	responses []*disgord.CreateInteractionResponse
+
+	// original is the ID of the message created by the response, if any
+	original disgord.Snowflake
+}
+
+// NewServer starts a fake Discord server. It must be closed by calling Close.
+func NewServer(conf ServerConfig) *Server {
+	if conf.BotToken == "" {
+		conf.BotToken = "fake-token"
+	}
+	if conf.Shards == 0 {
+		conf.Shards = 1
+	}
+	if conf.HeartbeatInterval == 0 {
+		conf.HeartbeatInterval = 41250 * time.Millisecond
+	}
+
+	s := &Server{
+		users:        make(map[disgord.Snowflake]*disgord.User),
+		guilds:       make(map[disgord.Snowflake]*disgord.Guild),
+		channels:     make(map[disgord.Snowflake]*disgord.Channel),
+		messages:     make(map[disgord.Snowflake][]*disgord.Message),
+		members:      make(map[disgord.Snowflake]map[disgord.Snowflake]*disgord.Member),
+		interactions: make(map[string]*interaction),
+		gw:           newGatewayState(),
+	}
+
+	bot := &disgord.User{Username: "fake-bot", Discriminator: 1}
+	if conf.Bot != nil {
+		clone(conf.Bot, bot)
+	}
+	if bot.ID.IsZero() {
+		bot.ID = s.newID()
+	}
+	bot.Bot = true
+	conf.Bot = bot
+	s.users[bot.ID] = bot
+	s.conf = conf
+
+	s.http = httptest.NewServer(s)
+	return s
+}
+
+// Close disconnects every shard and stops the server.
+func (s *Server) Close() {
+	s.gw.closeAll()
+	s.http.Close()
+}
+
+// URL returns the base URL of the server, such as "http://127.0.0.1:41234".
+func (s *Server) URL() string {
+	return s.http.URL
+}
+
+// RESTURL returns the URL to use for disgord.Config.RESTBaseURL.
+func (s *Server) RESTURL() string {
+	return s.http.URL + "/api"
+}
+
+// GatewayURL returns the URL of the gateway websocket, which is also given by /gateway/bot.
+func (s *Server) GatewayURL() string {
+	return "ws" + strings.TrimPrefix(s.http.URL, "http") + "/gateway"
+}
+
+// Bot returns the user of the bot.
+func (s *Server) Bot() *disgord.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bot := &disgord.User{}
+	clone(s.conf.Bot, bot)
+	return bot
+}
+
+// ClientConfig returns a disgord configuration that uses the server. Shards identify every 10 milliseconds,
+// instead of every five seconds.
+func (s *Server) ClientConfig() disgord.Config {
+	return disgord.Config{
+		BotToken:    s.conf.BotToken,
+		RESTBaseURL: s.RESTURL(),
+		ShardConfig: disgord.ShardConfig{
+			URL:            s.GatewayURL(),
+			ShardRateLimit: 10 * time.Millisecond,
+		},
+	}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/gateway" {
+		s.serveGateway(w, r)
+		return
+	}
+	s.serveREST(w, r)
+}
+
+// newID returns a new snowflake. The IDs are sequential, such that guilds are spread over the shards.
+// The lock must be held, unless the server has not started.
+func (s *Server) newID() disgord.Snowflake {
+	s.lastID++
+	return disgord.Snowflake(s.lastID<<22 | s.lastID&0xfff)
+}
+
+// clone copies the value of src to dst through JSON, such that the state of the server is not shared.
+func clone(src, dst interface{}) {
+	data, err := json.Marshal(src)
+	if err != nil {
+		panic(err)
+	}
+	if err = json.Unmarshal(data, dst); err != nil {
+		panic(err)
+	}
+}
+
+// patch updates dst with the fields given in the JSON object, like a PATCH request.
+func patch(dst interface{}, data []byte) error {
+	var changes map[string]json.RawMessage
+	if err := json.Unmarshal(data, &changes); err != nil {
+		return err
+	}
+
+	current, err := json.Marshal(dst)
+	if err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(current, &fields); err != nil {
+		return err
+	}
+	for name, value := range changes {
+		fields[name] = value
+	}
+	if current, err = json.Marshal(fields); err != nil {
+		return err
+	}
+
+	v := reflect.ValueOf(dst).Elem()
+	v.Set(reflect.Zero(v.Type()))
+	return json.Unmarshal(current, dst)
+}
+
+func sortedIDs(ids []disgord.Snowflake) []disgord.Snowflake {
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+	return ids
+}
+
+//////////////////////////////////////////////////////
+//
+// STATE
+//
+//////////////////////////////////////////////////////
+
+// CreateUser adds a user, that can be added to guilds with AddMember.
+func (s *Server) CreateUser(username string) *disgord.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user := &disgord.User{
+		ID:            s.newID(),
+		Username:      username,
+		Discriminator: disgord.Discriminator(len(s.users) % 10000),
+	}
+	s.users[user.ID] = user
+
+	cp := &disgord.User{}
+	clone(user, cp)
+	return cp
+}
+
+// CreateGuild adds a guild owned by the bot, with an @everyone role. A GUILD_CREATE event is dispatched
+// if the shard of the guild is connected, otherwise the guild is sent once the shard is ready.
+func (s *Server) CreateGuild(name string) *disgord.Guild {
+	s.mu.Lock()
+	id := s.newID()
+	s.guilds[id] = &disgord.Guild{
+		ID:      id,
+		Name:    name,
+		OwnerID: s.conf.Bot.ID,
+		Roles: []*disgord.Role{{
+			ID:          id,
+			Name:        "@everyone",
+			Permissions: disgord.PermissionViewChannel | disgord.PermissionSendMessages | disgord.PermissionReadMessageHistory,
+		}},
+		Emojis:   []*disgord.Emoji{},
+		Features: []string{},
+	}
+	s.members[id] = make(map[disgord.Snowflake]*disgord.Member)
+	s.addMember(id, s.conf.Bot.ID)
+	guild := s.guildCreatePayload(id)
+	s.mu.Unlock()
+
+	s.dispatchGuild(id, "GUILD_CREATE", guild)
+	return guild
+}
+
+// CreateChannel adds a channel to the guild and dispatches a CHANNEL_CREATE event.
+func (s *Server) CreateChannel(guildID disgord.Snowflake, name string, channelType disgord.ChannelType) *disgord.Channel {
+	s.mu.Lock()
+	if _, ok := s.guilds[guildID]; !ok {
+		s.mu.Unlock()
+		panic("unknown guild " + guildID.String())
+	}
+	channel := &disgord.Channel{
+		ID:       s.newID(),
+		GuildID:  guildID,
+		Name:     name,
+		Type:     channelType,
+		Position: len(s.guildChannels(guildID)),
+	}
+	s.channels[channel.ID] = channel
+	cp := &disgord.Channel{}
+	clone(channel, cp)
+	s.mu.Unlock()
+
+	s.dispatchGuild(guildID, "CHANNEL_CREATE", cp)
+	return cp
+}
+
+// CreateRole adds a role to the guild and dispatches a GUILD_ROLE_CREATE event.
+func (s *Server) CreateRole(guildID disgord.Snowflake, name string, permissions disgord.PermissionBit) *disgord.Role {
+	s.mu.Lock()
+	guild, ok := s.guilds[guildID]
+	if !ok {
+		s.mu.Unlock()
+		panic("unknown guild " + guildID.String())
+	}
+	role := &disgord.Role{
+		ID:          s.newID(),
+		Name:        name,
+		Permissions: permissions,
+		Position:    len(guild.Roles),
+	}
+	guild.Roles = append(guild.Roles, role)
+	cp := &disgord.Role{}
+	clone(role, cp)
+	s.mu.Unlock()
+
+	s.dispatchGuild(guildID, "GUILD_ROLE_CREATE", &guildRole{GuildID: guildID, Role: cp})
+	return cp
+}
+
+// AddMember adds a user to the guild and dispatches a GUILD_MEMBER_ADD event.
+func (s *Server) AddMember(guildID, userID disgord.Snowflake, roleIDs ...disgord.Snowflake) *disgord.Member {
+	s.mu.Lock()
+	if _, ok := s.guilds[guildID]; !ok {
+		s.mu.Unlock()
+		panic("unknown guild " + guildID.String())
+	}
+	if _, ok := s.users[userID]; !ok {
+		s.mu.Unlock()
+		panic("unknown user " + userID.String())
+	}
+	member := s.addMember(guildID, userID)
+	member.Roles = append(member.Roles, roleIDs...)
+	cp := s.member(guildID, userID)
+	s.mu.Unlock()
+
+	s.dispatchGuild(guildID, "GUILD_MEMBER_ADD", cp)
+	return cp
+}
+
+// addMember adds a user to the guild. The lock must be held.
+func (s *Server) addMember(guildID, userID disgord.Snowflake) *disgord.Member {
+	if member, ok := s.members[guildID][userID]; ok {
+		return member
+	}
+	member := &disgord.Member{
+		GuildID:  guildID,
+		User:     s.users[userID],
+		UserID:   userID,
+		Roles:    []disgord.Snowflake{},
+		JoinedAt: disgord.Time{Time: time.Now()},
+	}
+	s.members[guildID][userID] = member
+	return member
+}
+
+// CreateMessage adds a message sent by the given user, and dispatches a MESSAGE_CREATE event.
+func (s *Server) CreateMessage(channelID, authorID disgord.Snowflake, content string) *disgord.Message {
+	s.mu.Lock()
+	if _, ok := s.channels[channelID]; !ok {
+		s.mu.Unlock()
+		panic("unknown channel " + channelID.String())
+	}
+	if _, ok := s.users[authorID]; !ok {
+		s.mu.Unlock()
+		panic("unknown user " + authorID.String())
+	}
+	msg := s.addMessage(channelID, authorID, &disgord.Message{Content: content})
+	s.mu.Unlock()
+
+	s.dispatchGuild(msg.GuildID, "MESSAGE_CREATE", msg)
+	return msg
+}
+
+// addMessage stores a message with the server controlled fields set, and returns a copy. The lock must be held.
+func (s *Server) addMessage(channelID, authorID disgord.Snowflake, msg *disgord.Message) *disgord.Message {
+	channel := s.channels[channelID]
+	msg.ID = s.newID()
+	msg.ChannelID = channelID
+	msg.GuildID = channel.GuildID
+	msg.Author = s.users[authorID]
+	msg.Timestamp = disgord.Time{Time: time.Now()}
+	msg.Member = nil
+	if member, ok := s.members[channel.GuildID][authorID]; ok {
+		msg.Member = &disgord.Member{}
+		clone(member, msg.Member)
+		msg.Member.User = nil
+	}
+	s.messages[channelID] = append(s.messages[channelID], msg)
+	channel.LastMessageID = msg.ID
+
+	cp := &disgord.Message{}
+	clone(msg, cp)
+	return cp
+}
+
+// CreateInteraction dispatches an INTERACTION_CREATE event. The ID, application ID and token are set
+// when missing, and the responses of the bot can be found with InteractionResponses.
+func (s *Server) CreateInteraction(evt *disgord.InteractionCreate) *disgord.InteractionCreate {
+	s.mu.Lock()
+	cp := &disgord.InteractionCreate{}
+	clone(evt, cp)
+	if cp.ID.IsZero() {
+		cp.ID = s.newID()
+	}
+	if cp.ApplicationID.IsZero() {
+		cp.ApplicationID = s.conf.Bot.ID
+	}
+	if cp.Token == "" {
+		cp.Token = "interaction-token-" + cp.ID.String()
+	}
+	if cp.Version == 0 {
+		cp.Version = 1
+	}
+	if cp.Member != nil && cp.Member.User != nil {
+		cp.Member.GuildID = cp.GuildID
+		cp.Member.UserID = cp.Member.User.ID
+	}
+	s.interactions[cp.Token] = &interaction{evt: cp}
+	s.mu.Unlock()
+
+	s.dispatchGuild(cp.GuildID, "INTERACTION_CREATE", cp)
+	return cp
+}
+
+// InteractionResponses returns the responses sent by the bot to the interaction.
+func (s *Server) InteractionResponses(interactionID disgord.Snowflake) []*disgord.CreateInteractionResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var responses []*disgord.CreateInteractionResponse
+	for _, i := range s.interactions {
+		if i.evt.ID != interactionID {
+			continue
+		}
+		for _, response := range i.responses {
+			cp := &disgord.CreateInteractionResponse{}
+			clone(response, cp)
+			responses = append(responses, cp)
+		}
+	}
+	return responses
+}
+
+// Guild returns the guild with its channels, members and roles, or nil.
+func (s *Server) Guild(id disgord.Snowflake) *disgord.Guild {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.guilds[id]; !ok {
+		return nil
+	}
+	return s.guildCreatePayload(id)
+}
+
+// Channel returns the channel, or nil.
+func (s *Server) Channel(id disgord.Snowflake) *disgord.Channel {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	channel, ok := s.channels[id]
+	if !ok {
+		return nil
+	}
+	cp := &disgord.Channel{}
+	clone(channel, cp)
+	return cp
+}
+
+// Messages returns the messages of the channel, the oldest first.
+func (s *Server) Messages(channelID disgord.Snowflake) []*disgord.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	messages := make([]*disgord.Message, len(s.messages[channelID]))
+	for i, msg := range s.messages[channelID] {
+		messages[i] = &disgord.Message{}
+		clone(msg, messages[i])
+	}
+	return messages
+}
+
+// Member returns the member of the guild, or nil.
+func (s *Server) Member(guildID, userID disgord.Snowflake) *disgord.Member {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.member(guildID, userID)
+}
+
+// member returns a copy of the member, or nil. The lock must be held.
+func (s *Server) member(guildID, userID disgord.Snowflake) *disgord.Member {
+	member, ok := s.members[guildID][userID]
+	if !ok {
+		return nil
+	}
+	cp := &disgord.Member{}
+	clone(member, cp)
+	cp.GuildID = guildID
+	cp.UserID = userID
+	return cp
+}
+
+// guildChannels returns the channels of the guild, ordered by ID. The lock must be held.
+func (s *Server) guildChannels(guildID disgord.Snowflake) []*disgord.Channel {
+	var ids []disgord.Snowflake
+	for id, channel := range s.channels {
+		if channel.GuildID == guildID && !guildID.IsZero() {
+			ids = append(ids, id)
+		}
+	}
+	channels := make([]*disgord.Channel, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		cp := &disgord.Channel{}
+		clone(s.channels[id], cp)
+		channels = append(channels, cp)
+	}
+	return channels
+}
+
+// guildMembers returns the members of the guild, ordered by user ID. The lock must be held.
+func (s *Server) guildMembers(guildID disgord.Snowflake) []*disgord.Member {
+	ids := make([]disgord.Snowflake, 0, len(s.members[guildID]))
+	for id := range s.members[guildID] {
+		ids = append(ids, id)
+	}
+	members := make([]*disgord.Member, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		members = append(members, s.member(guildID, id))
+	}
+	return members
+}
+
+// guildCreatePayload returns a copy of the guild, with its channels and members. The lock must be held.
+func (s *Server) guildCreatePayload(id disgord.Snowflake) *disgord.Guild {
+	guild := &disgord.Guild{}
+	clone(s.guilds[id], guild)
+	guild.Channels = s.guildChannels(id)
+	guild.Members = s.guildMembers(id)
+	guild.MemberCount = uint(len(guild.Members))
+	if member, ok := s.members[id][s.conf.Bot.ID]; ok {
+		joinedAt := member.JoinedAt
+		guild.JoinedAt = &joinedAt
+	}
+	return guild
+}
+
+// guildRole is the payload of the GUILD_ROLE_CREATE and GUILD_ROLE_UPDATE events.
+type guildRole struct {
+	GuildID disgord.Snowflake `json:"guild_id"`
+	Role    *disgord.Role     `json:"role"`
+}
+
+func formatID(id disgord.Snowflake) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
@@ -0,0 +1,509 @@
+package disgordutil
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"nhooyr.io/websocket"
+
+	"github.com/andersfylling/disgord"
+	"github.com/andersfylling/disgord/json"
+)
+
+// gateway opcodes, https://discord.com/developers/docs/topics/opcodes-and-status-codes#gateway-gateway-opcodes
+const (
+	opDispatch            = 0
+	opHeartbeat           = 1
+	opIdentify            = 2
+	opResume              = 6
+	opReconnect           = 7
+	opRequestGuildMembers = 8
+	opInvalidSession      = 9
+	opHello               = 10
+	opHeartbeatAck        = 11
+)
+
+// gateway close codes, https://discord.com/developers/docs/topics/opcodes-and-status-codes#gateway-gateway-close-event-codes
+const (
+	closeAuthenticationFailed = 4004
+	closeInvalidShard         = 4010
+)
+
+const gatewayWriteTimeout = 5 * time.Second
+
+type gatewayPacket struct {
+	Op    int             `json:"op"`
+	Data  json.RawMessage `json:"d"`
+	Seq   uint32          `json:"s,omitempty"`
+	Event string          `json:"t,omitempty"`
+}
+
+// gatewayConn is a websocket connection of a shard.
+type gatewayConn struct {
+	mu   sync.Mutex
+	conn *websocket.Conn
+}
+
+func (c *gatewayConn) write(p *gatewayPacket) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), gatewayWriteTimeout)
+	defer cancel()
+	return c.conn.Write(ctx, websocket.MessageText, data)
+}
+
+func (c *gatewayConn) close(code int, reason string) {
+	_ = c.conn.Close(websocket.StatusCode(code), reason)
+}
+
+// gatewaySession is the session a shard identified with. Dispatched events are kept, such that they can be
+// sent again when the shard resumes.
+type gatewaySession struct {
+	mu         sync.Mutex
+	id         string
+	shardID    uint
+	shardCount uint
+	conn       *gatewayConn // nil while the shard is disconnected
+	seq        uint32
+	sent       []*gatewayPacket
+	invalid    bool
+}
+
+func (s *gatewaySession) dispatch(event string, data json.RawMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.invalid {
+		return
+	}
+
+	s.seq++
+	p := &gatewayPacket{Op: opDispatch, Data: data, Seq: s.seq, Event: event}
+	s.sent = append(s.sent, p)
+	if s.conn != nil {
+		// on failure the shard will have to resume
+		_ = s.conn.write(p)
+	}
+}
+
+// resume attaches the connection to the session, and sends the events after the given sequence number.
+func (s *gatewaySession) resume(conn *gatewayConn, seq uint32) {
+	s.mu.Lock()
+	if s.conn != nil && s.conn != conn {
+		s.conn.close(int(websocket.StatusNormalClosure), "resumed elsewhere")
+	}
+	s.conn = conn
+	for _, p := range s.sent {
+		if p.Seq > seq {
+			_ = conn.write(p)
+		}
+	}
+	s.mu.Unlock()
+
+	s.dispatch("RESUMED", json.RawMessage("{}"))
+}
+
+func (s *gatewaySession) detach(conn *gatewayConn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == conn {
+		s.conn = nil
+	}
+}
+
+func (s *gatewaySession) connection() *gatewayConn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn
+}
+
+type gatewayState struct {
+	mu          sync.Mutex
+	lastSession int
+	sessions    map[string]*gatewaySession
+	shards      map[uint]*gatewaySession
+	conns       map[*gatewayConn]struct{}
+	identifies  int
+	resumes     int
+}
+
+func newGatewayState() gatewayState {
+	return gatewayState{
+		sessions: make(map[string]*gatewaySession),
+		shards:   make(map[uint]*gatewaySession),
+		conns:    make(map[*gatewayConn]struct{}),
+	}
+}
+
+func (g *gatewayState) closeAll() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for conn := range g.conns {
+		conn.close(int(websocket.StatusGoingAway), "server is shutting down")
+	}
+}
+
+// sessionsForGuild returns the sessions of the shard that the guild belongs to.
+func (g *gatewayState) sessionsForGuild(guildID disgord.Snowflake) []*gatewaySession {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var sessions []*gatewaySession
+	for shardID, session := range g.shards {
+		if guildID.IsZero() && shardID == 0 || !guildID.IsZero() && disgord.ShardID(guildID, session.shardCount) == shardID {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions
+}
+
+func (g *gatewayState) shard(shardID uint) (*gatewaySession, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	session, ok := g.shards[shardID]
+	if !ok {
+		return nil, errors.New("shard " + strconv.FormatUint(uint64(shardID), 10) + " has not identified")
+	}
+	return session, nil
+}
+
+// Dispatch sends an event to the shard, such as "MESSAGE_REACTION_ADD". The data is encoded as JSON. When the
+// shard is disconnected, the event is sent once it resumes.
+func (s *Server) Dispatch(shardID uint, event string, data interface{}) error {
+	session, err := s.gw.shard(shardID)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	session.dispatch(event, payload)
+	return nil
+}
+
+// DispatchGuild sends an event to the shard of the guild, or to shard 0 when the guild ID is 0.
+func (s *Server) DispatchGuild(guildID disgord.Snowflake, event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	for _, session := range s.gw.sessionsForGuild(guildID) {
+		session.dispatch(event, payload)
+	}
+	return nil
+}
+
+func (s *Server) dispatchGuild(guildID disgord.Snowflake, event string, data interface{}) {
+	if err := s.DispatchGuild(guildID, event, data); err != nil {
+		panic(err)
+	}
+}
+
+// RequestReconnect sends a RECONNECT packet to the shard, which makes it reconnect and resume.
+func (s *Server) RequestReconnect(shardID uint) error {
+	session, err := s.gw.shard(shardID)
+	if err != nil {
+		return err
+	}
+	conn := session.connection()
+	if conn == nil {
+		return errors.New("shard is disconnected")
+	}
+	return conn.write(&gatewayPacket{Op: opReconnect, Data: json.RawMessage("null")})
+}
+
+// CloseConnection closes the websocket connection of the shard with the given close code, such as 4000 for
+// an unknown error, which the shard should resume after.
+func (s *Server) CloseConnection(shardID uint, code int) error {
+	session, err := s.gw.shard(shardID)
+	if err != nil {
+		return err
+	}
+	conn := session.connection()
+	if conn == nil {
+		return errors.New("shard is disconnected")
+	}
+	session.detach(conn)
+	conn.close(code, "closed by the test")
+	return nil
+}
+
+// InvalidateSession sends an INVALID_SESSION packet to the shard, which makes it identify again after
+// one to five seconds.
+func (s *Server) InvalidateSession(shardID uint) error {
+	session, err := s.gw.shard(shardID)
+	if err != nil {
+		return err
+	}
+	session.mu.Lock()
+	session.invalid = true
+	conn := session.conn
+	session.mu.Unlock()
+	if conn == nil {
+		return errors.New("shard is disconnected")
+	}
+	return conn.write(&gatewayPacket{Op: opInvalidSession, Data: json.RawMessage("false")})
+}
+
+// ConnectedShards returns the IDs of the shards that are connected and have identified or resumed.
+func (s *Server) ConnectedShards() []uint {
+	s.gw.mu.Lock()
+	defer s.gw.mu.Unlock()
+
+	var shardIDs []uint
+	for shardID, session := range s.gw.shards {
+		if session.connection() != nil {
+			shardIDs = append(shardIDs, shardID)
+		}
+	}
+	return shardIDs
+}
+
+// Identifies returns the number of IDENTIFY packets received.
+func (s *Server) Identifies() int {
+	s.gw.mu.Lock()
+	defer s.gw.mu.Unlock()
+	return s.gw.identifies
+}
+
+// Resumes returns the number of sessions that were resumed.
+func (s *Server) Resumes() int {
+	s.gw.mu.Lock()
+	defer s.gw.mu.Unlock()
+	return s.gw.resumes
+}
+
+func (s *Server) serveGateway(w http.ResponseWriter, r *http.Request) {
+	ws, err := websocket.Accept(w, r, nil)
+	if err != nil {
+		return
+	}
+	ws.SetReadLimit(1 << 20)
+	conn := &gatewayConn{conn: ws}
+
+	s.gw.mu.Lock()
+	s.gw.conns[conn] = struct{}{}
+	s.gw.mu.Unlock()
+	defer func() {
+		s.gw.mu.Lock()
+		delete(s.gw.conns, conn)
+		s.gw.mu.Unlock()
+		conn.close(int(websocket.StatusNormalClosure), "")
+	}()
+
+	hello, _ := json.Marshal(map[string]interface{}{
+		"heartbeat_interval": s.conf.HeartbeatInterval.Milliseconds(),
+	})
+	if err = conn.write(&gatewayPacket{Op: opHello, Data: hello}); err != nil {
+		return
+	}
+
+	var session *gatewaySession
+	defer func() {
+		if session != nil {
+			session.detach(conn)
+		}
+	}()
+	for {
+		_, data, err := ws.Read(context.Background())
+		if err != nil {
+			return
+		}
+		var p gatewayPacket
+		if err = json.Unmarshal(data, &p); err != nil {
+			conn.close(4002, "Error while decoding payload.")
+			return
+		}
+
+		switch p.Op {
+		case opHeartbeat:
+			err = conn.write(&gatewayPacket{Op: opHeartbeatAck, Data: json.RawMessage("null")})
+		case opIdentify:
+			if session != nil {
+				session.detach(conn)
+			}
+			if session, err = s.identify(conn, p.Data); err != nil {
+				return
+			}
+		case opResume:
+			if session != nil {
+				session.detach(conn)
+			}
+			session, err = s.resume(conn, p.Data)
+		case opRequestGuildMembers:
+			if session != nil {
+				s.requestGuildMembers(session, p.Data)
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (s *Server) identify(conn *gatewayConn, data json.RawMessage) (*gatewaySession, error) {
+	var identify struct {
+		Token string   `json:"token"`
+		Shard *[2]uint `json:"shard"`
+	}
+	if err := json.Unmarshal(data, &identify); err != nil {
+		conn.close(4002, "Error while decoding payload.")
+		return nil, err
+	}
+	if identify.Token != s.conf.BotToken {
+		conn.close(closeAuthenticationFailed, "Authentication failed.")
+		return nil, errors.New("authentication failed")
+	}
+	shard := [2]uint{0, 1}
+	if identify.Shard != nil {
+		shard = *identify.Shard
+	}
+	if shard[1] == 0 || shard[0] >= shard[1] {
+		conn.close(closeInvalidShard, "Invalid shard.")
+		return nil, errors.New("invalid shard")
+	}
+
+	s.gw.mu.Lock()
+	s.gw.identifies++
+	s.gw.lastSession++
+	session := &gatewaySession{
+		id:         "fake-session-" + strconv.Itoa(s.gw.lastSession),
+		shardID:    shard[0],
+		shardCount: shard[1],
+		conn:       conn,
+	}
+	if previous, ok := s.gw.shards[session.shardID]; ok {
+		previous.mu.Lock()
+		previous.invalid = true
+		previous.mu.Unlock()
+	}
+	s.gw.sessions[session.id] = session
+	s.gw.shards[session.shardID] = session
+	s.gw.mu.Unlock()
+
+	// the guilds of the shard are sent as unavailable in READY, and then in GUILD_CREATE events
+	s.mu.Lock()
+	bot := &disgord.User{}
+	clone(s.conf.Bot, bot)
+	var guildIDs []disgord.Snowflake
+	for id := range s.guilds {
+		if disgord.ShardID(id, session.shardCount) == session.shardID {
+			guildIDs = append(guildIDs, id)
+		}
+	}
+	unavailable := make([]*disgord.GuildUnavailable, 0, len(guildIDs))
+	guilds := make([]*disgord.Guild, 0, len(guildIDs))
+	for _, id := range sortedIDs(guildIDs) {
+		unavailable = append(unavailable, &disgord.GuildUnavailable{ID: id, Unavailable: true})
+		guilds = append(guilds, s.guildCreatePayload(id))
+	}
+	s.mu.Unlock()
+
+	ready, err := json.Marshal(map[string]interface{}{
+		"v":           9,
+		"user":        bot,
+		"guilds":      unavailable,
+		"session_id":  session.id,
+		"shard":       shard,
+		"application": map[string]interface{}{"id": bot.ID, "flags": 0},
+	})
+	if err != nil {
+		return nil, err
+	}
+	session.dispatch("READY", ready)
+	for _, guild := range guilds {
+		payload, err := json.Marshal(guild)
+		if err != nil {
+			return nil, err
+		}
+		session.dispatch("GUILD_CREATE", payload)
+	}
+	return session, nil
+}
+
+func (s *Server) resume(conn *gatewayConn, data json.RawMessage) (*gatewaySession, error) {
+	var resume struct {
+		Token     string `json:"token"`
+		SessionID string `json:"session_id"`
+		Seq       uint32 `json:"seq"`
+	}
+	if err := json.Unmarshal(data, &resume); err != nil {
+		conn.close(4002, "Error while decoding payload.")
+		return nil, err
+	}
+	if resume.Token != s.conf.BotToken {
+		conn.close(closeAuthenticationFailed, "Authentication failed.")
+		return nil, errors.New("authentication failed")
+	}
+
+	s.gw.mu.Lock()
+	session, ok := s.gw.sessions[resume.SessionID]
+	if ok {
+		session.mu.Lock()
+		ok = !session.invalid
+		session.mu.Unlock()
+	}
+	if ok {
+		s.gw.resumes++
+	}
+	s.gw.mu.Unlock()
+
+	if !ok {
+		return nil, conn.write(&gatewayPacket{Op: opInvalidSession, Data: json.RawMessage("false")})
+	}
+	session.resume(conn, resume.Seq)
+	return session, nil
+}
+
+// requestGuildMembers answers REQUEST_GUILD_MEMBERS with every member of the guilds in one chunk.
+func (s *Server) requestGuildMembers(session *gatewaySession, data json.RawMessage) {
+	var request struct {
+		GuildID json.RawMessage `json:"guild_id"`
+		Nonce   string          `json:"nonce"`
+	}
+	if err := json.Unmarshal(data, &request); err != nil {
+		return
+	}
+	var guildIDs []disgord.Snowflake
+	if err := json.Unmarshal(request.GuildID, &guildIDs); err != nil {
+		var guildID disgord.Snowflake
+		if err = json.Unmarshal(request.GuildID, &guildID); err != nil {
+			return
+		}
+		guildIDs = []disgord.Snowflake{guildID}
+	}
+
+	for _, guildID := range guildIDs {
+		s.mu.Lock()
+		_, ok := s.guilds[guildID]
+		var members []*disgord.Member
+		if ok {
+			members = s.guildMembers(guildID)
+		}
+		s.mu.Unlock()
+		if !ok {
+			continue
+		}
+
+		chunk, err := json.Marshal(map[string]interface{}{
+			"guild_id":    guildID,
+			"members":     members,
+			"chunk_index": 0,
+			"chunk_count": 1,
+			"nonce":       request.Nonce,
+		})
+		if err == nil {
+			session.dispatch("GUILD_MEMBERS_CHUNK", chunk)
+		}
+	}
+}
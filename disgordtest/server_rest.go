@@ -0,0 +1,1038 @@
+package disgordutil
+
+import (
+	"bytes"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andersfylling/disgord"
+	"github.com/andersfylling/disgord/json"
+)
+
+// JSON error codes, https://discord.com/developers/docs/topics/opcodes-and-status-codes#json-json-error-codes
+const (
+	errCodeUnknownChannel     = 10003
+	errCodeUnknownGuild       = 10004
+	errCodeUnknownMember      = 10007
+	errCodeUnknownMessage     = 10008
+	errCodeUnknownRole        = 10011
+	errCodeUnknownUser        = 10013
+	errCodeUnknownWebhook     = 10015
+	errCodeUnknownInteraction = 10062
+	errCodeAlreadyResponded   = 40060
+	errCodeInvalidFormBody    = 50035
+)
+
+// restError is the JSON body of an error response.
+type restError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type restRequest struct {
+	*http.Request
+	params map[string]string
+	body   []byte
+}
+
+// id returns a snowflake of the path, such as "guild" for /guilds/{guild}.
+func (r *restRequest) id(name string) disgord.Snowflake {
+	id, _ := disgord.GetSnowflake(r.params[name])
+	return id
+}
+
+// decode reads the JSON body, which can also be the payload_json field of a multipart form.
+func (r *restRequest) decode(v interface{}) error {
+	body, err := r.jsonBody()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+func (r *restRequest) jsonBody() ([]byte, error) {
+	mediaType, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		if len(r.body) == 0 {
+			return []byte("{}"), nil
+		}
+		return r.body, nil
+	}
+
+	form, err := multipart.NewReader(bytes.NewReader(r.body), params["boundary"]).ReadForm(32 << 20)
+	if err != nil {
+		return nil, err
+	}
+	defer form.RemoveAll()
+	if payload := form.Value["payload_json"]; len(payload) > 0 {
+		return []byte(payload[0]), nil
+	}
+	return []byte("{}"), nil
+}
+
+func (r *restRequest) queryInt(name string, fallback int) int {
+	if v, err := strconv.Atoi(r.URL.Query().Get(name)); err == nil {
+		return v
+	}
+	return fallback
+}
+
+func (r *restRequest) querySnowflake(name string) disgord.Snowflake {
+	id, _ := disgord.GetSnowflake(r.URL.Query().Get(name))
+	return id
+}
+
+type restHandler func(s *Server, r *restRequest) (int, interface{})
+
+type restRoute struct {
+	method  string
+	path    []string
+	handler restHandler
+}
+
+func route(method, path string, handler restHandler) *restRoute {
+	return &restRoute{
+		method:  method,
+		path:    strings.Split(strings.Trim(path, "/"), "/"),
+		handler: handler,
+	}
+}
+
+// match returns the parameters of the path, such as "guild" for /guilds/{guild}, or nil.
+func (rt *restRoute) match(segments []string) map[string]string {
+	if len(segments) != len(rt.path) {
+		return nil
+	}
+	params := make(map[string]string)
+	for i, segment := range rt.path {
+		if strings.HasPrefix(segment, "{") {
+			params[strings.Trim(segment, "{}")] = segments[i]
+		} else if segment != segments[i] {
+			return nil
+		}
+	}
+	return params
+}
+
+// restRoutes are matched in order, such that /guilds/{guild}/members/@me comes before
+// /guilds/{guild}/members/{user}.
+var restRoutes = []*restRoute{
+	route(http.MethodGet, "/gateway", (*Server).getGateway),
+	route(http.MethodGet, "/gateway/bot", (*Server).getGateway),
+
+	route(http.MethodGet, "/users/@me", (*Server).getCurrentUser),
+	route(http.MethodGet, "/users/@me/guilds", (*Server).getCurrentUserGuilds),
+	route(http.MethodPost, "/users/@me/channels", (*Server).createDM),
+	route(http.MethodGet, "/users/{user}", (*Server).getUser),
+
+	route(http.MethodGet, "/guilds/{guild}", (*Server).getGuild),
+	route(http.MethodPatch, "/guilds/{guild}", (*Server).updateGuild),
+	route(http.MethodGet, "/guilds/{guild}/channels", (*Server).getGuildChannels),
+	route(http.MethodPost, "/guilds/{guild}/channels", (*Server).createGuildChannel),
+	route(http.MethodGet, "/guilds/{guild}/members", (*Server).getMembers),
+	route(http.MethodPatch, "/guilds/{guild}/members/@me", (*Server).updateCurrentMember),
+	route(http.MethodGet, "/guilds/{guild}/members/{user}", (*Server).getMember),
+	route(http.MethodPut, "/guilds/{guild}/members/{user}", (*Server).putMember),
+	route(http.MethodPatch, "/guilds/{guild}/members/{user}", (*Server).updateMember),
+	route(http.MethodDelete, "/guilds/{guild}/members/{user}", (*Server).removeMember),
+	route(http.MethodPut, "/guilds/{guild}/members/{user}/roles/{role}", (*Server).addMemberRole),
+	route(http.MethodDelete, "/guilds/{guild}/members/{user}/roles/{role}", (*Server).removeMemberRole),
+	route(http.MethodGet, "/guilds/{guild}/roles", (*Server).getRoles),
+	route(http.MethodPost, "/guilds/{guild}/roles", (*Server).createRole),
+	route(http.MethodPatch, "/guilds/{guild}/roles/{role}", (*Server).updateRole),
+	route(http.MethodDelete, "/guilds/{guild}/roles/{role}", (*Server).deleteRole),
+
+	route(http.MethodGet, "/channels/{channel}", (*Server).getChannel),
+	route(http.MethodPatch, "/channels/{channel}", (*Server).updateChannel),
+	route(http.MethodDelete, "/channels/{channel}", (*Server).deleteChannel),
+	route(http.MethodPost, "/channels/{channel}/typing", (*Server).triggerTyping),
+	route(http.MethodGet, "/channels/{channel}/messages", (*Server).getMessages),
+	route(http.MethodPost, "/channels/{channel}/messages", (*Server).createMessage),
+	route(http.MethodPost, "/channels/{channel}/messages/bulk-delete", (*Server).bulkDeleteMessages),
+	route(http.MethodGet, "/channels/{channel}/messages/{message}", (*Server).getMessage),
+	route(http.MethodPatch, "/channels/{channel}/messages/{message}", (*Server).updateMessage),
+	route(http.MethodDelete, "/channels/{channel}/messages/{message}", (*Server).deleteMessage),
+
+	route(http.MethodPost, "/interactions/{interaction}/{token}/callback", (*Server).createInteractionResponse),
+	route(http.MethodPost, "/webhooks/{application}/{token}", (*Server).createFollowupMessage),
+	route(http.MethodGet, "/webhooks/{application}/{token}/messages/{message}", (*Server).getInteractionMessage),
+	route(http.MethodPatch, "/webhooks/{application}/{token}/messages/{message}", (*Server).updateInteractionMessage),
+	route(http.MethodDelete, "/webhooks/{application}/{token}/messages/{message}", (*Server).deleteInteractionMessage),
+}
+
+func (s *Server) serveREST(w http.ResponseWriter, r *http.Request) {
+	// /api/v{version}/...
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(segments) < 3 || segments[0] != "api" || !strings.HasPrefix(segments[1], "v") {
+		writeJSON(w, http.StatusNotFound, &restError{Message: "404: Not Found"})
+		return
+	}
+	segments = segments[2:]
+
+	// interaction responses are authorized by the token in the path
+	if r.Header.Get("Authorization") != "Bot "+s.conf.BotToken && segments[0] != "interactions" && segments[0] != "webhooks" {
+		writeJSON(w, http.StatusUnauthorized, &restError{Message: "401: Unauthorized"})
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, &restError{Code: errCodeInvalidFormBody, Message: err.Error()})
+		return
+	}
+
+	var methodNotAllowed bool
+	for _, rt := range restRoutes {
+		params := rt.match(segments)
+		if params == nil {
+			continue
+		}
+		if rt.method != r.Method {
+			methodNotAllowed = true
+			continue
+		}
+		code, v := rt.handler(s, &restRequest{Request: r, params: params, body: body})
+		writeJSON(w, code, v)
+		return
+	}
+
+	if methodNotAllowed {
+		writeJSON(w, http.StatusMethodNotAllowed, &restError{Message: "405: Method Not Allowed"})
+		return
+	}
+	writeJSON(w, http.StatusNotFound, &restError{Message: "404: Not Found"})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	if code == http.StatusNoContent || v == nil {
+		w.WriteHeader(code)
+		return
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		code = http.StatusInternalServerError
+		data, _ = json.Marshal(&restError{Message: err.Error()})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(data)
+}
+
+func errNotFound(code int, message string) (int, interface{}) {
+	return http.StatusNotFound, &restError{Code: code, Message: message}
+}
+
+func errInvalidBody(err error) (int, interface{}) {
+	return http.StatusBadRequest, &restError{Code: errCodeInvalidFormBody, Message: "Invalid Form Body: " + err.Error()}
+}
+
+//////////////////////////////////////////////////////
+//
+// GATEWAY & USERS
+//
+//////////////////////////////////////////////////////
+
+func (s *Server) getGateway(r *restRequest) (int, interface{}) {
+	return http.StatusOK, map[string]interface{}{
+		"url":    s.GatewayURL(),
+		"shards": s.conf.Shards,
+		"session_start_limit": map[string]interface{}{
+			"total":           1000,
+			"remaining":       1000,
+			"reset_after":     0,
+			"max_concurrency": 1,
+		},
+	}
+}
+
+func (s *Server) getCurrentUser(r *restRequest) (int, interface{}) {
+	return http.StatusOK, s.Bot()
+}
+
+func (s *Server) getUser(r *restRequest) (int, interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[r.id("user")]
+	if !ok {
+		return errNotFound(errCodeUnknownUser, "Unknown User")
+	}
+	return http.StatusOK, user
+}
+
+func (s *Server) getCurrentUserGuilds(r *restRequest) (int, interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var ids []disgord.Snowflake
+	for id := range s.guilds {
+		if _, ok := s.members[id][s.conf.Bot.ID]; ok {
+			ids = append(ids, id)
+		}
+	}
+	guilds := make([]map[string]interface{}, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		guild := s.guilds[id]
+		guilds = append(guilds, map[string]interface{}{
+			"id":          guild.ID,
+			"name":        guild.Name,
+			"icon":        guild.Icon,
+			"owner":       guild.OwnerID == s.conf.Bot.ID,
+			"permissions": strconv.FormatUint(uint64(disgord.PermissionAll), 10),
+			"features":    guild.Features,
+		})
+	}
+	return http.StatusOK, guilds
+}
+
+func (s *Server) createDM(r *restRequest) (int, interface{}) {
+	var params struct {
+		RecipientID disgord.Snowflake `json:"recipient_id"`
+	}
+	if err := r.decode(&params); err != nil {
+		return errInvalidBody(err)
+	}
+
+	s.mu.Lock()
+	recipient, ok := s.users[params.RecipientID]
+	if !ok {
+		s.mu.Unlock()
+		return errNotFound(errCodeUnknownUser, "Unknown User")
+	}
+	for _, channel := range s.channels {
+		if channel.Type == disgord.ChannelTypeDM && len(channel.Recipients) == 1 && channel.Recipients[0].ID == recipient.ID {
+			defer s.mu.Unlock()
+			return http.StatusOK, channel
+		}
+	}
+	channel := &disgord.Channel{
+		ID:         s.newID(),
+		Type:       disgord.ChannelTypeDM,
+		Recipients: []*disgord.User{recipient},
+	}
+	s.channels[channel.ID] = channel
+	cp := &disgord.Channel{}
+	clone(channel, cp)
+	s.mu.Unlock()
+
+	s.dispatchGuild(0, "CHANNEL_CREATE", cp)
+	return http.StatusOK, cp
+}
+
+//////////////////////////////////////////////////////
+//
+// GUILDS
+//
+//////////////////////////////////////////////////////
+
+func (s *Server) getGuild(r *restRequest) (int, interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	guild, ok := s.guilds[r.id("guild")]
+	if !ok {
+		return errNotFound(errCodeUnknownGuild, "Unknown Guild")
+	}
+	return http.StatusOK, guild
+}
+
+func (s *Server) updateGuild(r *restRequest) (int, interface{}) {
+	body, err := r.jsonBody()
+	if err != nil {
+		return errInvalidBody(err)
+	}
+
+	s.mu.Lock()
+	guild, ok := s.guilds[r.id("guild")]
+	if !ok {
+		s.mu.Unlock()
+		return errNotFound(errCodeUnknownGuild, "Unknown Guild")
+	}
+	id, roles := guild.ID, guild.Roles
+	if err = patch(guild, body); err != nil {
+		s.mu.Unlock()
+		return errInvalidBody(err)
+	}
+	guild.ID, guild.Roles = id, roles
+	cp := &disgord.Guild{}
+	clone(guild, cp)
+	s.mu.Unlock()
+
+	s.dispatchGuild(cp.ID, "GUILD_UPDATE", cp)
+	return http.StatusOK, cp
+}
+
+func (s *Server) getGuildChannels(r *restRequest) (int, interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.guilds[r.id("guild")]; !ok {
+		return errNotFound(errCodeUnknownGuild, "Unknown Guild")
+	}
+	return http.StatusOK, s.guildChannels(r.id("guild"))
+}
+
+func (s *Server) createGuildChannel(r *restRequest) (int, interface{}) {
+	channel := &disgord.Channel{}
+	if err := r.decode(channel); err != nil {
+		return errInvalidBody(err)
+	}
+
+	s.mu.Lock()
+	if _, ok := s.guilds[r.id("guild")]; !ok {
+		s.mu.Unlock()
+		return errNotFound(errCodeUnknownGuild, "Unknown Guild")
+	}
+	channel.ID = s.newID()
+	channel.GuildID = r.id("guild")
+	s.channels[channel.ID] = channel
+	cp := &disgord.Channel{}
+	clone(channel, cp)
+	s.mu.Unlock()
+
+	s.dispatchGuild(cp.GuildID, "CHANNEL_CREATE", cp)
+	return http.StatusCreated, cp
+}
+
+func (s *Server) getMembers(r *restRequest) (int, interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.guilds[r.id("guild")]; !ok {
+		return errNotFound(errCodeUnknownGuild, "Unknown Guild")
+	}
+	limit, after := r.queryInt("limit", 1), r.querySnowflake("after")
+	members := make([]*disgord.Member, 0, limit)
+	for _, member := range s.guildMembers(r.id("guild")) {
+		if len(members) == limit {
+			break
+		}
+		if member.User.ID > after {
+			members = append(members, member)
+		}
+	}
+	return http.StatusOK, members
+}
+
+func (s *Server) getMember(r *restRequest) (int, interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.guilds[r.id("guild")]; !ok {
+		return errNotFound(errCodeUnknownGuild, "Unknown Guild")
+	}
+	member := s.member(r.id("guild"), r.id("user"))
+	if member == nil {
+		return errNotFound(errCodeUnknownMember, "Unknown Member")
+	}
+	return http.StatusOK, member
+}
+
+func (s *Server) putMember(r *restRequest) (int, interface{}) {
+	var params struct {
+		Roles []disgord.Snowflake `json:"roles"`
+	}
+	if err := r.decode(&params); err != nil {
+		return errInvalidBody(err)
+	}
+
+	s.mu.Lock()
+	guildID, userID := r.id("guild"), r.id("user")
+	if _, ok := s.guilds[guildID]; !ok {
+		s.mu.Unlock()
+		return errNotFound(errCodeUnknownGuild, "Unknown Guild")
+	}
+	if _, ok := s.users[userID]; !ok {
+		s.mu.Unlock()
+		return errNotFound(errCodeUnknownUser, "Unknown User")
+	}
+	if _, ok := s.members[guildID][userID]; ok {
+		s.mu.Unlock()
+		return http.StatusNoContent, nil
+	}
+	member := s.addMember(guildID, userID)
+	member.Roles = append(member.Roles, params.Roles...)
+	cp := s.member(guildID, userID)
+	s.mu.Unlock()
+
+	s.dispatchGuild(guildID, "GUILD_MEMBER_ADD", cp)
+	return http.StatusCreated, cp
+}
+
+func (s *Server) updateCurrentMember(r *restRequest) (int, interface{}) {
+	r.params["user"] = formatID(s.Bot().ID)
+	return s.updateMember(r)
+}
+
+func (s *Server) updateMember(r *restRequest) (int, interface{}) {
+	body, err := r.jsonBody()
+	if err != nil {
+		return errInvalidBody(err)
+	}
+	return s.changeMember(r.id("guild"), r.id("user"), func(member *disgord.Member) error {
+		user := member.User
+		if err := patch(member, body); err != nil {
+			return err
+		}
+		member.User = user
+		return nil
+	})
+}
+
+func (s *Server) addMemberRole(r *restRequest) (int, interface{}) {
+	code, v := s.changeMember(r.id("guild"), r.id("user"), func(member *disgord.Member) error {
+		for _, id := range member.Roles {
+			if id == r.id("role") {
+				return nil
+			}
+		}
+		member.Roles = append(member.Roles, r.id("role"))
+		return nil
+	})
+	if code != http.StatusOK {
+		return code, v
+	}
+	return http.StatusNoContent, nil
+}
+
+func (s *Server) removeMemberRole(r *restRequest) (int, interface{}) {
+	code, v := s.changeMember(r.id("guild"), r.id("user"), func(member *disgord.Member) error {
+		member.Roles = removeID(member.Roles, r.id("role"))
+		return nil
+	})
+	if code != http.StatusOK {
+		return code, v
+	}
+	return http.StatusNoContent, nil
+}
+
+// changeMember updates a member and dispatches a GUILD_MEMBER_UPDATE event.
+func (s *Server) changeMember(guildID, userID disgord.Snowflake, change func(member *disgord.Member) error) (int, interface{}) {
+	s.mu.Lock()
+	if _, ok := s.guilds[guildID]; !ok {
+		s.mu.Unlock()
+		return errNotFound(errCodeUnknownGuild, "Unknown Guild")
+	}
+	member, ok := s.members[guildID][userID]
+	if !ok {
+		s.mu.Unlock()
+		return errNotFound(errCodeUnknownMember, "Unknown Member")
+	}
+	if err := change(member); err != nil {
+		s.mu.Unlock()
+		return errInvalidBody(err)
+	}
+	member.GuildID, member.UserID = guildID, userID
+	cp := s.member(guildID, userID)
+	s.mu.Unlock()
+
+	s.dispatchGuild(guildID, "GUILD_MEMBER_UPDATE", cp)
+	return http.StatusOK, cp
+}
+
+func (s *Server) removeMember(r *restRequest) (int, interface{}) {
+	s.mu.Lock()
+	guildID, userID := r.id("guild"), r.id("user")
+	member, ok := s.members[guildID][userID]
+	if !ok {
+		s.mu.Unlock()
+		return errNotFound(errCodeUnknownMember, "Unknown Member")
+	}
+	delete(s.members[guildID], userID)
+	user := &disgord.User{}
+	clone(member.User, user)
+	s.mu.Unlock()
+
+	s.dispatchGuild(guildID, "GUILD_MEMBER_REMOVE", map[string]interface{}{"guild_id": guildID, "user": user})
+	return http.StatusNoContent, nil
+}
+
+func (s *Server) getRoles(r *restRequest) (int, interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	guild, ok := s.guilds[r.id("guild")]
+	if !ok {
+		return errNotFound(errCodeUnknownGuild, "Unknown Guild")
+	}
+	return http.StatusOK, guild.Roles
+}
+
+func (s *Server) createRole(r *restRequest) (int, interface{}) {
+	role := &disgord.Role{}
+	if err := r.decode(role); err != nil {
+		return errInvalidBody(err)
+	}
+
+	s.mu.Lock()
+	guild, ok := s.guilds[r.id("guild")]
+	if !ok {
+		s.mu.Unlock()
+		return errNotFound(errCodeUnknownGuild, "Unknown Guild")
+	}
+	role.ID = s.newID()
+	role.Position = len(guild.Roles)
+	if role.Name == "" {
+		role.Name = "new role"
+	}
+	guild.Roles = append(guild.Roles, role)
+	cp := &disgord.Role{}
+	clone(role, cp)
+	s.mu.Unlock()
+
+	s.dispatchGuild(guild.ID, "GUILD_ROLE_CREATE", &guildRole{GuildID: guild.ID, Role: cp})
+	return http.StatusOK, cp
+}
+
+func (s *Server) updateRole(r *restRequest) (int, interface{}) {
+	body, err := r.jsonBody()
+	if err != nil {
+		return errInvalidBody(err)
+	}
+
+	s.mu.Lock()
+	guild, ok := s.guilds[r.id("guild")]
+	if !ok {
+		s.mu.Unlock()
+		return errNotFound(errCodeUnknownGuild, "Unknown Guild")
+	}
+	var role *disgord.Role
+	for _, existing := range guild.Roles {
+		if existing.ID == r.id("role") {
+			role = existing
+		}
+	}
+	if role == nil {
+		s.mu.Unlock()
+		return errNotFound(errCodeUnknownRole, "Unknown Role")
+	}
+	if err = patch(role, body); err != nil {
+		s.mu.Unlock()
+		return errInvalidBody(err)
+	}
+	role.ID = r.id("role")
+	cp := &disgord.Role{}
+	clone(role, cp)
+	s.mu.Unlock()
+
+	s.dispatchGuild(guild.ID, "GUILD_ROLE_UPDATE", &guildRole{GuildID: guild.ID, Role: cp})
+	return http.StatusOK, cp
+}
+
+func (s *Server) deleteRole(r *restRequest) (int, interface{}) {
+	s.mu.Lock()
+	guildID, roleID := r.id("guild"), r.id("role")
+	guild, ok := s.guilds[guildID]
+	if !ok {
+		s.mu.Unlock()
+		return errNotFound(errCodeUnknownGuild, "Unknown Guild")
+	}
+	roles := guild.Roles[:0]
+	for _, role := range guild.Roles {
+		if role.ID != roleID {
+			roles = append(roles, role)
+		}
+	}
+	if len(roles) == len(guild.Roles) {
+		s.mu.Unlock()
+		return errNotFound(errCodeUnknownRole, "Unknown Role")
+	}
+	guild.Roles = roles
+	for _, member := range s.members[guildID] {
+		member.Roles = removeID(member.Roles, roleID)
+	}
+	s.mu.Unlock()
+
+	s.dispatchGuild(guildID, "GUILD_ROLE_DELETE", map[string]interface{}{"guild_id": guildID, "role_id": roleID})
+	return http.StatusNoContent, nil
+}
+
+func removeID(ids []disgord.Snowflake, id disgord.Snowflake) []disgord.Snowflake {
+	kept := make([]disgord.Snowflake, 0, len(ids))
+	for i := range ids {
+		if ids[i] != id {
+			kept = append(kept, ids[i])
+		}
+	}
+	return kept
+}
+
+//////////////////////////////////////////////////////
+//
+// CHANNELS & MESSAGES
+//
+//////////////////////////////////////////////////////
+
+func (s *Server) getChannel(r *restRequest) (int, interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	channel, ok := s.channels[r.id("channel")]
+	if !ok {
+		return errNotFound(errCodeUnknownChannel, "Unknown Channel")
+	}
+	return http.StatusOK, channel
+}
+
+func (s *Server) updateChannel(r *restRequest) (int, interface{}) {
+	body, err := r.jsonBody()
+	if err != nil {
+		return errInvalidBody(err)
+	}
+
+	s.mu.Lock()
+	channel, ok := s.channels[r.id("channel")]
+	if !ok {
+		s.mu.Unlock()
+		return errNotFound(errCodeUnknownChannel, "Unknown Channel")
+	}
+	id, guildID := channel.ID, channel.GuildID
+	if err = patch(channel, body); err != nil {
+		s.mu.Unlock()
+		return errInvalidBody(err)
+	}
+	channel.ID, channel.GuildID = id, guildID
+	cp := &disgord.Channel{}
+	clone(channel, cp)
+	s.mu.Unlock()
+
+	s.dispatchGuild(cp.GuildID, "CHANNEL_UPDATE", cp)
+	return http.StatusOK, cp
+}
+
+func (s *Server) deleteChannel(r *restRequest) (int, interface{}) {
+	s.mu.Lock()
+	channel, ok := s.channels[r.id("channel")]
+	if !ok {
+		s.mu.Unlock()
+		return errNotFound(errCodeUnknownChannel, "Unknown Channel")
+	}
+	delete(s.channels, channel.ID)
+	delete(s.messages, channel.ID)
+	s.mu.Unlock()
+
+	s.dispatchGuild(channel.GuildID, "CHANNEL_DELETE", channel)
+	return http.StatusOK, channel
+}
+
+func (s *Server) triggerTyping(r *restRequest) (int, interface{}) {
+	if s.Channel(r.id("channel")) == nil {
+		return errNotFound(errCodeUnknownChannel, "Unknown Channel")
+	}
+	return http.StatusNoContent, nil
+}
+
+// getMessages returns the messages of the channel, the newest first.
+func (s *Server) getMessages(r *restRequest) (int, interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.channels[r.id("channel")]; !ok {
+		return errNotFound(errCodeUnknownChannel, "Unknown Channel")
+	}
+	limit := r.queryInt("limit", 50)
+	before, after := r.querySnowflake("before"), r.querySnowflake("after")
+
+	var messages []*disgord.Message
+	for _, msg := range s.messages[r.id("channel")] {
+		if (before.IsZero() || msg.ID < before) && msg.ID > after {
+			messages = append(messages, msg)
+		}
+	}
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].ID > messages[j].ID
+	})
+	if around := r.querySnowflake("around"); !around.IsZero() {
+		// the messages closest to around, such that the message itself is in the middle
+		center := sort.Search(len(messages), func(i int) bool {
+			return messages[i].ID <= around
+		})
+		start := center - limit/2
+		if start < 0 {
+			start = 0
+		}
+		end := start + limit
+		if end > len(messages) {
+			end = len(messages)
+		}
+		messages = messages[start:end]
+	}
+	if len(messages) > limit {
+		if after.IsZero() {
+			messages = messages[:limit]
+		} else {
+			// the messages closest to after
+			messages = messages[len(messages)-limit:]
+		}
+	}
+	if messages == nil {
+		messages = []*disgord.Message{}
+	}
+	return http.StatusOK, messages
+}
+
+func (s *Server) createMessage(r *restRequest) (int, interface{}) {
+	msg := &disgord.Message{}
+	if err := r.decode(msg); err != nil {
+		return errInvalidBody(err)
+	}
+
+	s.mu.Lock()
+	if _, ok := s.channels[r.id("channel")]; !ok {
+		s.mu.Unlock()
+		return errNotFound(errCodeUnknownChannel, "Unknown Channel")
+	}
+	cp := s.addMessage(r.id("channel"), s.conf.Bot.ID, msg)
+	s.mu.Unlock()
+
+	s.dispatchGuild(cp.GuildID, "MESSAGE_CREATE", cp)
+	return http.StatusOK, cp
+}
+
+func (s *Server) getMessage(r *restRequest) (int, interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	msg := s.findMessage(r.id("channel"), r.id("message"))
+	if msg == nil {
+		return errNotFound(errCodeUnknownMessage, "Unknown Message")
+	}
+	return http.StatusOK, msg
+}
+
+// findMessage returns the stored message, or nil. The lock must be held.
+func (s *Server) findMessage(channelID, messageID disgord.Snowflake) *disgord.Message {
+	for _, msg := range s.messages[channelID] {
+		if msg.ID == messageID {
+			return msg
+		}
+	}
+	return nil
+}
+
+func (s *Server) updateMessage(r *restRequest) (int, interface{}) {
+	body, err := r.jsonBody()
+	if err != nil {
+		return errInvalidBody(err)
+	}
+	return s.changeMessage(r.id("channel"), r.id("message"), body)
+}
+
+// changeMessage patches a message and dispatches a MESSAGE_UPDATE event.
+func (s *Server) changeMessage(channelID, messageID disgord.Snowflake, body []byte) (int, interface{}) {
+	s.mu.Lock()
+	msg := s.findMessage(channelID, messageID)
+	if msg == nil {
+		s.mu.Unlock()
+		return errNotFound(errCodeUnknownMessage, "Unknown Message")
+	}
+	kept := *msg
+	if err := patch(msg, body); err != nil {
+		s.mu.Unlock()
+		return errInvalidBody(err)
+	}
+	msg.ID, msg.ChannelID, msg.GuildID = kept.ID, kept.ChannelID, kept.GuildID
+	msg.Author, msg.Member, msg.Timestamp = kept.Author, kept.Member, kept.Timestamp
+	msg.EditedTimestamp = disgord.Time{Time: time.Now()}
+	cp := &disgord.Message{}
+	clone(msg, cp)
+	s.mu.Unlock()
+
+	s.dispatchGuild(cp.GuildID, "MESSAGE_UPDATE", cp)
+	return http.StatusOK, cp
+}
+
+func (s *Server) deleteMessage(r *restRequest) (int, interface{}) {
+	return s.removeMessages(r.id("channel"), []disgord.Snowflake{r.id("message")})
+}
+
+func (s *Server) bulkDeleteMessages(r *restRequest) (int, interface{}) {
+	var params struct {
+		Messages []disgord.Snowflake `json:"messages"`
+	}
+	if err := r.decode(&params); err != nil {
+		return errInvalidBody(err)
+	}
+	return s.removeMessages(r.id("channel"), params.Messages)
+}
+
+// removeMessages deletes the messages, and dispatches MESSAGE_DELETE for one message, or MESSAGE_DELETE_BULK.
+func (s *Server) removeMessages(channelID disgord.Snowflake, ids []disgord.Snowflake) (int, interface{}) {
+	s.mu.Lock()
+	channel, ok := s.channels[channelID]
+	if !ok {
+		s.mu.Unlock()
+		return errNotFound(errCodeUnknownChannel, "Unknown Channel")
+	}
+	remove := make(map[disgord.Snowflake]bool, len(ids))
+	for _, id := range ids {
+		remove[id] = true
+	}
+	var deleted []disgord.Snowflake
+	kept := s.messages[channelID][:0]
+	for _, msg := range s.messages[channelID] {
+		if remove[msg.ID] {
+			deleted = append(deleted, msg.ID)
+		} else {
+			kept = append(kept, msg)
+		}
+	}
+	s.messages[channelID] = kept
+	s.mu.Unlock()
+
+	if len(deleted) == 0 {
+		return errNotFound(errCodeUnknownMessage, "Unknown Message")
+	}
+	if len(ids) == 1 {
+		s.dispatchGuild(channel.GuildID, "MESSAGE_DELETE", map[string]interface{}{
+			"id":         deleted[0],
+			"channel_id": channelID,
+			"guild_id":   channel.GuildID,
+		})
+	} else {
+		s.dispatchGuild(channel.GuildID, "MESSAGE_DELETE_BULK", map[string]interface{}{
+			"ids":        deleted,
+			"channel_id": channelID,
+			"guild_id":   channel.GuildID,
+		})
+	}
+	return http.StatusNoContent, nil
+}
+
+//////////////////////////////////////////////////////
+//
+// INTERACTIONS
+//
+//////////////////////////////////////////////////////
+
+func (s *Server) createInteractionResponse(r *restRequest) (int, interface{}) {
+	response := &disgord.CreateInteractionResponse{}
+	if err := r.decode(response); err != nil {
+		return errInvalidBody(err)
+	}
+
+	s.mu.Lock()
+	i, ok := s.interactions[r.params["token"]]
+	if !ok || i.evt.ID != r.id("interaction") {
+		s.mu.Unlock()
+		return errNotFound(errCodeUnknownInteraction, "Unknown interaction")
+	}
+	if len(i.responses) > 0 {
+		s.mu.Unlock()
+		return http.StatusBadRequest, &restError{Code: errCodeAlreadyResponded, Message: "Interaction has already been acknowledged."}
+	}
+	i.responses = append(i.responses, response)
+
+	var event string
+	var msg *disgord.Message
+	switch response.Type {
+	case disgord.InteractionCallbackChannelMessageWithSource, disgord.InteractionCallbackDeferredChannelMessageWithSource:
+		if _, ok := s.channels[i.evt.ChannelID]; ok {
+			created := &disgord.Message{}
+			if response.Data != nil {
+				clone(response.Data, created)
+			}
+			msg = s.addMessage(i.evt.ChannelID, s.conf.Bot.ID, created)
+			i.original = msg.ID
+			event = "MESSAGE_CREATE"
+		}
+	case disgord.InteractionCallbackUpdateMessage:
+		if i.evt.Message != nil && response.Data != nil {
+			if existing := s.findMessage(i.evt.Message.ChannelID, i.evt.Message.ID); existing != nil {
+				data, _ := json.Marshal(response.Data)
+				s.mu.Unlock()
+				s.changeMessage(existing.ChannelID, existing.ID, data)
+				return http.StatusNoContent, nil
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	if msg != nil && msg.Flags&disgord.MessageFlagEphemeral == 0 {
+		s.dispatchGuild(msg.GuildID, event, msg)
+	}
+	return http.StatusNoContent, nil
+}
+
+// interactionMessage returns the ID of a message sent in response to the interaction. The lock must be held.
+func (s *Server) interactionMessage(r *restRequest) (*interaction, disgord.Snowflake, bool) {
+	i, ok := s.interactions[r.params["token"]]
+	if !ok || i.evt.ApplicationID != r.id("application") {
+		return nil, 0, false
+	}
+	if r.params["message"] == "@original" {
+		return i, i.original, !i.original.IsZero()
+	}
+	return i, r.id("message"), true
+}
+
+func (s *Server) createFollowupMessage(r *restRequest) (int, interface{}) {
+	msg := &disgord.Message{}
+	if err := r.decode(msg); err != nil {
+		return errInvalidBody(err)
+	}
+
+	s.mu.Lock()
+	i, ok := s.interactions[r.params["token"]]
+	if !ok || i.evt.ApplicationID != r.id("application") {
+		s.mu.Unlock()
+		return errNotFound(errCodeUnknownWebhook, "Unknown Webhook")
+	}
+	if _, ok = s.channels[i.evt.ChannelID]; !ok {
+		s.mu.Unlock()
+		return errNotFound(errCodeUnknownChannel, "Unknown Channel")
+	}
+	cp := s.addMessage(i.evt.ChannelID, s.conf.Bot.ID, msg)
+	s.mu.Unlock()
+
+	if cp.Flags&disgord.MessageFlagEphemeral == 0 {
+		s.dispatchGuild(cp.GuildID, "MESSAGE_CREATE", cp)
+	}
+	return http.StatusOK, cp
+}
+
+func (s *Server) getInteractionMessage(r *restRequest) (int, interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i, id, ok := s.interactionMessage(r)
+	if !ok {
+		return errNotFound(errCodeUnknownMessage, "Unknown Message")
+	}
+	msg := s.findMessage(i.evt.ChannelID, id)
+	if msg == nil {
+		return errNotFound(errCodeUnknownMessage, "Unknown Message")
+	}
+	return http.StatusOK, msg
+}
+
+func (s *Server) updateInteractionMessage(r *restRequest) (int, interface{}) {
+	body, err := r.jsonBody()
+	if err != nil {
+		return errInvalidBody(err)
+	}
+
+	s.mu.Lock()
+	i, id, ok := s.interactionMessage(r)
+	s.mu.Unlock()
+	if !ok {
+		return errNotFound(errCodeUnknownMessage, "Unknown Message")
+	}
+	return s.changeMessage(i.evt.ChannelID, id, body)
+}
+
+func (s *Server) deleteInteractionMessage(r *restRequest) (int, interface{}) {
+	s.mu.Lock()
+	i, id, ok := s.interactionMessage(r)
+	s.mu.Unlock()
+	if !ok {
+		return errNotFound(errCodeUnknownMessage, "Unknown Message")
+	}
+	return s.removeMessages(i.evt.ChannelID, []disgord.Snowflake{id})
+}
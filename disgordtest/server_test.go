@@ -0,0 +1,238 @@
+//go:build !integration
+// +build !integration
+
+package disgordutil
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/andersfylling/disgord"
+)
+
+func newServerClient(t *testing.T, server *Server) *disgord.Client {
+	client, err := disgord.NewClient(context.Background(), server.ClientConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestServer_REST(t *testing.T) {
+	server := NewServer(ServerConfig{})
+	defer server.Close()
+
+	user := server.CreateUser("user")
+	guild := server.CreateGuild("guild")
+	channel := server.CreateChannel(guild.ID, "general", disgord.ChannelTypeGuildText)
+	role := server.CreateRole(guild.ID, "mod", disgord.PermissionKickMembers)
+	server.AddMember(guild.ID, user.ID)
+	server.CreateMessage(channel.ID, user.ID, "first")
+
+	client := newServerClient(t, server)
+
+	bot, err := client.CurrentUser().Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bot.ID != server.Bot().ID {
+		t.Errorf("expected the bot user, got %+v", bot)
+	}
+
+	msg, err := client.Channel(channel.ID).CreateMessage(&disgord.CreateMessage{Content: "second"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Author == nil || msg.Author.ID != bot.ID || msg.GuildID != guild.ID {
+		t.Errorf("expected a message by the bot in the guild, got %+v", msg)
+	}
+
+	msgs, err := client.Channel(channel.ID).GetMessages(&disgord.GetMessages{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 2 || msgs[0].Content != "second" || msgs[1].Content != "first" {
+		t.Errorf("expected the messages newest first, got %+v", msgs)
+	}
+
+	if _, err = client.Channel(channel.ID).Message(msg.ID).Update(&disgord.UpdateMessage{Content: stringPtr("edited")}); err != nil {
+		t.Fatal(err)
+	}
+	if stored := server.Messages(channel.ID); stored[1].Content != "edited" {
+		t.Errorf("expected the message to be edited, got %q", stored[1].Content)
+	}
+
+	if err = client.Guild(guild.ID).Member(user.ID).AddRole(role.ID); err != nil {
+		t.Fatal(err)
+	}
+	if member := server.Member(guild.ID, user.ID); len(member.Roles) != 1 || member.Roles[0] != role.ID {
+		t.Errorf("expected the role to be added, got %+v", member.Roles)
+	}
+
+	if err = client.Channel(channel.ID).Message(msg.ID).Delete(); err != nil {
+		t.Fatal(err)
+	}
+	if stored := server.Messages(channel.ID); len(stored) != 1 {
+		t.Errorf("expected the message to be deleted, got %d messages", len(stored))
+	}
+
+	if _, err = client.Channel(12345).WithFlags(disgord.IgnoreCache).Get(); !errors.Is(err, disgord.UnknownChannel) {
+		t.Errorf("expected an unknown channel error, got %v", err)
+	}
+
+	for _, path := range []string{"/api/v9", "/api/v9/"} {
+		resp, err := http.Get(server.URL() + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("expected %s to not be found, got %d", path, resp.StatusCode)
+		}
+	}
+}
+
+func TestServer_Gateway(t *testing.T) {
+	server := NewServer(ServerConfig{Shards: 2})
+	defer server.Close()
+
+	guilds := []*disgord.Guild{server.CreateGuild("shard 0"), server.CreateGuild("shard 1")}
+	if disgord.ShardID(guilds[0].ID, 2) == disgord.ShardID(guilds[1].ID, 2) {
+		t.Fatal("expected the guilds to be on different shards")
+	}
+	channel := server.CreateChannel(guilds[0].ID, "general", disgord.ChannelTypeGuildText)
+	user := server.CreateUser("user")
+
+	client := newServerClient(t, server)
+	guildCreates := make(chan *disgord.GuildCreate, 10)
+	messages := make(chan *disgord.MessageCreate, 10)
+	resumed := make(chan *disgord.Resumed, 10)
+	gateway := client.Gateway()
+	gateway.GuildCreate(func(_ disgord.Session, evt *disgord.GuildCreate) {
+		guildCreates <- evt
+	})
+	gateway.MessageCreate(func(_ disgord.Session, evt *disgord.MessageCreate) {
+		messages <- evt
+	})
+	gateway.Resumed(func(_ disgord.Session, evt *disgord.Resumed) {
+		resumed <- evt
+	})
+	if err := gateway.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = gateway.Disconnect()
+	}()
+
+	seen := make(map[disgord.Snowflake]uint)
+	for len(seen) < 2 {
+		select {
+		case evt := <-guildCreates:
+			seen[evt.Guild.ID] = evt.ShardID
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected a GUILD_CREATE for every guild, got %d", len(seen))
+		}
+	}
+	for _, guild := range guilds {
+		if shardID := disgord.ShardID(guild.ID, 2); seen[guild.ID] != shardID {
+			t.Errorf("expected guild %d on shard %d, got %d", guild.ID, shardID, seen[guild.ID])
+		}
+	}
+	if shards := server.ConnectedShards(); len(shards) != 2 {
+		t.Errorf("expected 2 connected shards, got %v", shards)
+	}
+
+	receive := func(content string) {
+		select {
+		case evt := <-messages:
+			if evt.Message.Content != content {
+				t.Errorf("expected message %q, got %q", content, evt.Message.Content)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected message %q", content)
+		}
+	}
+
+	server.CreateMessage(channel.ID, user.ID, "stateful")
+	receive("stateful")
+
+	err := server.DispatchGuild(guilds[0].ID, "MESSAGE_CREATE", &disgord.Message{
+		ID:        1,
+		ChannelID: channel.ID,
+		GuildID:   guilds[0].ID,
+		Author:    user,
+		Content:   "custom",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	receive("custom")
+
+	// events dispatched while disconnected are replayed when the shard resumes
+	if err = server.CloseConnection(disgord.ShardID(guilds[0].ID, 2), 4000); err != nil {
+		t.Fatal(err)
+	}
+	server.CreateMessage(channel.ID, user.ID, "missed")
+	select {
+	case <-resumed:
+	case <-time.After(10 * time.Second):
+		t.Fatal("expected the shard to resume")
+	}
+	receive("missed")
+	if server.Resumes() != 1 || server.Identifies() != 2 {
+		t.Errorf("expected 2 identifies and 1 resume, got %d and %d", server.Identifies(), server.Resumes())
+	}
+}
+
+func TestServer_Interaction(t *testing.T) {
+	server := NewServer(ServerConfig{})
+	defer server.Close()
+
+	guild := server.CreateGuild("guild")
+	channel := server.CreateChannel(guild.ID, "general", disgord.ChannelTypeGuildText)
+	user := server.CreateUser("user")
+	member := server.AddMember(guild.ID, user.ID)
+
+	client := newServerClient(t, server)
+	evt := server.CreateInteraction(&disgord.InteractionCreate{
+		Type:      disgord.InteractionApplicationCommand,
+		GuildID:   guild.ID,
+		ChannelID: channel.ID,
+		Member:    member,
+		Data:      &disgord.ApplicationCommandInteractionData{Name: "ping"},
+	})
+
+	ctx := context.Background()
+	err := evt.Reply(ctx, client, &disgord.CreateInteractionResponse{
+		Type: disgord.InteractionCallbackChannelMessageWithSource,
+		Data: &disgord.CreateInteractionResponseData{Content: "pong"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if responses := server.InteractionResponses(evt.ID); len(responses) != 1 || responses[0].Data.Content != "pong" {
+		t.Errorf("expected the response to be stored, got %+v", responses)
+	}
+
+	if err = evt.Edit(ctx, client, &disgord.UpdateMessage{Content: stringPtr("pong!")}); err != nil {
+		t.Fatal(err)
+	}
+	if msgs := server.Messages(channel.ID); len(msgs) != 1 || msgs[0].Content != "pong!" {
+		t.Errorf("expected the original response to be edited, got %+v", msgs)
+	}
+
+	err = evt.Reply(ctx, client, &disgord.CreateInteractionResponse{
+		Type: disgord.InteractionCallbackChannelMessageWithSource,
+		Data: &disgord.CreateInteractionResponseData{Content: "again"},
+	})
+	if !errors.Is(err, disgord.InteractionAlreadyAcknowledged) {
+		t.Errorf("expected the interaction to be acknowledged once, got %v", err)
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
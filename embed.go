@@ -1,7 +1,7 @@
 package disgord
 
 // limitations: https://discord.com/developers/docs/resources/channel#embed-limits
-// TODO: implement NewEmbedX functions that ensures limitations
+// See NewEmbed for a builder that verifies the limits, and the Embed*Limit constants.
 
 type EmbedType string
 
@@ -0,0 +1,349 @@
+package disgord
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// EmbedBuilder creates rich embeds through chained method calls:
+//
+//	msg, err := disgord.NewEmbed().
+//		Title("Server stats").
+//		ColorHex("#5865F2").
+//		Field("Members", "1024", true).
+//		ImageFile("graph.png", file).
+//		Message()
+//
+// The embed limits are verified by Build, BuildAll and Message, which return a *ErrInvalidPayload listing every
+// violation. With SplitOverflow, descriptions and fields that don't fit in the current embed are moved to new
+// embeds instead, which are grouped into as few messages as possible by Messages.
+type EmbedBuilder struct {
+	embeds []*Embed
+	files  []CreateMessageFile
+	split  bool
+	err    error
+
+	// placed on the last embed when building
+	image     *EmbedImage
+	footer    *EmbedFooter
+	timestamp Time
+}
+
+// NewEmbed creates a builder for a rich embed.
+func NewEmbed() *EmbedBuilder {
+	return &EmbedBuilder{
+		embeds: []*Embed{{Type: EmbedTypeRich}},
+	}
+}
+
+func (b *EmbedBuilder) first() *Embed {
+	return b.embeds[0]
+}
+
+func (b *EmbedBuilder) current() *Embed {
+	return b.embeds[len(b.embeds)-1]
+}
+
+// next starts a new embed for overflowing content, using the color of the first embed.
+func (b *EmbedBuilder) next() *Embed {
+	embed := &Embed{Type: EmbedTypeRich, Color: b.first().Color}
+	b.embeds = append(b.embeds, embed)
+	return embed
+}
+
+// SplitOverflow moves content that exceeds the limits of the current embed to new embeds. The title, URL,
+// author and thumbnail are kept on the first embed, while the image, footer and timestamp are placed on the last.
+func (b *EmbedBuilder) SplitOverflow() *EmbedBuilder {
+	b.split = true
+	return b
+}
+
+// Title sets the title of the first embed.
+func (b *EmbedBuilder) Title(title string) *EmbedBuilder {
+	b.first().Title = title
+	return b
+}
+
+// URL sets the URL the title links to.
+func (b *EmbedBuilder) URL(url string) *EmbedBuilder {
+	b.first().URL = url
+	return b
+}
+
+// Description sets the description of the current embed. With SplitOverflow, a description
+// above EmbedDescriptionLimit is split over new embeds, preferably at line breaks.
+func (b *EmbedBuilder) Description(description string) *EmbedBuilder {
+	if !b.split {
+		b.current().Description = description
+		return b
+	}
+
+	chunks := splitText(description, EmbedDescriptionLimit)
+	embed := b.current()
+	for i, chunk := range chunks {
+		if i > 0 || embedLength(embed)+utf8.RuneCountInString(chunk)+b.lastLength() > EmbedTotalLimit {
+			embed = b.next()
+		}
+		embed.Description = chunk
+	}
+	return b
+}
+
+// Color sets the color of the embeds, such as 0xFF0000 for red.
+func (b *EmbedBuilder) Color(color int) *EmbedBuilder {
+	for _, embed := range b.embeds {
+		embed.Color = color
+	}
+	return b
+}
+
+// ColorRGB sets the color of the embeds from its red, green and blue components.
+func (b *EmbedBuilder) ColorRGB(red, green, blue uint8) *EmbedBuilder {
+	return b.Color(int(red)<<16 | int(green)<<8 | int(blue))
+}
+
+// ColorHex sets the color of the embeds from a hex string, such as "#FF0000", "0xFF0000" or "FF0000".
+// The short form "#F00" is expanded to "#FF0000".
+func (b *EmbedBuilder) ColorHex(hex string) *EmbedBuilder {
+	trimmed := strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(hex), "#"), "0x")
+	if len(trimmed) == 3 {
+		trimmed = string([]byte{trimmed[0], trimmed[0], trimmed[1], trimmed[1], trimmed[2], trimmed[2]})
+	}
+	color, err := strconv.ParseUint(trimmed, 16, 32)
+	if err != nil || len(trimmed) != 6 {
+		b.setErr(fmt.Errorf("color %q is not a valid hex color: %w", hex, ErrIllegalValue))
+		return b
+	}
+	return b.Color(int(color))
+}
+
+// Author sets the author of the first embed. The URL and icon URL are optional.
+func (b *EmbedBuilder) Author(name, url, iconURL string) *EmbedBuilder {
+	b.first().Author = &EmbedAuthor{Name: name, URL: url, IconURL: iconURL}
+	return b
+}
+
+// Footer sets the footer of the last embed. The icon URL is optional. With SplitOverflow, a new embed is
+// started when the footer does not fit in the current embed.
+func (b *EmbedBuilder) Footer(text, iconURL string) *EmbedBuilder {
+	b.footer = &EmbedFooter{Text: text, IconURL: iconURL}
+	if b.split && embedLength(b.current())+b.lastLength() > EmbedTotalLimit {
+		b.next()
+	}
+	return b
+}
+
+// Timestamp sets the timestamp shown next to the footer of the last embed.
+func (b *EmbedBuilder) Timestamp(t time.Time) *EmbedBuilder {
+	b.timestamp = Time{t}
+	return b
+}
+
+// Thumbnail sets the thumbnail of the first embed.
+func (b *EmbedBuilder) Thumbnail(url string) *EmbedBuilder {
+	b.first().Thumbnail = &EmbedThumbnail{URL: url}
+	return b
+}
+
+// ThumbnailFile uploads a local file together with the message and uses it as the thumbnail.
+func (b *EmbedBuilder) ThumbnailFile(fileName string, r io.Reader) *EmbedBuilder {
+	return b.Thumbnail(b.attach(fileName, r))
+}
+
+// Image sets the image of the last embed.
+func (b *EmbedBuilder) Image(url string) *EmbedBuilder {
+	b.image = &EmbedImage{URL: url}
+	return b
+}
+
+// ImageFile uploads a local file together with the message and uses it as the image.
+func (b *EmbedBuilder) ImageFile(fileName string, r io.Reader) *EmbedBuilder {
+	return b.Image(b.attach(fileName, r))
+}
+
+// attach adds a file to the message and returns the URL referencing it.
+func (b *EmbedBuilder) attach(fileName string, r io.Reader) string {
+	b.files = append(b.files, CreateMessageFile{Reader: r, FileName: fileName})
+	return "attachment://" + fileName
+}
+
+// Field adds a field to the current embed. With SplitOverflow, a new embed is started when the
+// current embed already holds EmbedFieldsLimit fields or the field would exceed EmbedTotalLimit.
+// Without SplitOverflow, the builder fails as soon as a field exceeds the limits.
+func (b *EmbedBuilder) Field(name, value string, inline bool) *EmbedBuilder {
+	embed := b.current()
+	if !b.split {
+		embed.Fields = append(embed.Fields, &EmbedField{Name: name, Value: value, Inline: inline})
+
+		v := &payloadValidator{}
+		v.limit("", v.embed("", embed)+b.lastLength(), EmbedTotalLimit)
+		b.setErr(v.err())
+		return b
+	}
+
+	length := utf8.RuneCountInString(name) + utf8.RuneCountInString(value)
+	if len(embed.Fields) >= EmbedFieldsLimit || embedLength(embed)+length+b.lastLength() > EmbedTotalLimit {
+		embed = b.next()
+	}
+	embed.Fields = append(embed.Fields, &EmbedField{Name: name, Value: value, Inline: inline})
+	return b
+}
+
+// lastLength returns the length of the image, footer and timestamp, which are placed on the last embed when
+// building.
+func (b *EmbedBuilder) lastLength() int {
+	return embedLength(&Embed{Image: b.image, Footer: b.footer, Timestamp: b.timestamp})
+}
+
+func (b *EmbedBuilder) setErr(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// Files returns the local files referenced by the embeds, which must be sent in the same message.
+func (b *EmbedBuilder) Files() []CreateMessageFile {
+	return b.files
+}
+
+// BuildAll returns every embed created by the builder, or an error when an embed exceeds the limits.
+// Note that Discord also limits the combined length of the embeds in a message, see Messages.
+func (b *EmbedBuilder) BuildAll() ([]*Embed, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	embeds := make([]*Embed, len(b.embeds))
+	for i := range b.embeds {
+		embeds[i] = DeepCopy(b.embeds[i]).(*Embed)
+	}
+	last := embeds[len(embeds)-1]
+	if b.image != nil {
+		last.Image = DeepCopy(b.image).(*EmbedImage)
+	}
+	if b.footer != nil {
+		last.Footer = DeepCopy(b.footer).(*EmbedFooter)
+	}
+	last.Timestamp = b.timestamp
+
+	v := &payloadValidator{}
+	for i, embed := range embeds {
+		path := ""
+		if len(embeds) > 1 {
+			path = "embeds." + strconv.Itoa(i)
+		}
+		v.limit(path, v.embed(path, embed), EmbedTotalLimit)
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	return embeds, nil
+}
+
+// Build returns the embed. Use BuildAll or Messages when SplitOverflow created several embeds.
+func (b *EmbedBuilder) Build() (*Embed, error) {
+	embeds, err := b.BuildAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(embeds) > 1 {
+		return nil, fmt.Errorf("the content was split over %d embeds, use BuildAll: %w", len(embeds), ErrIllegalValue)
+	}
+	return embeds[0], nil
+}
+
+// Message returns a message holding the embeds and the local files they reference.
+func (b *EmbedBuilder) Message() (*CreateMessage, error) {
+	embeds, err := b.BuildAll()
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &CreateMessage{Embeds: embeds, Files: b.files}
+	if err = msg.FindErrors(); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// Messages groups the embeds into as few messages as the message limits allow. Files are sent with
+// the message holding the embed that references them.
+func (b *EmbedBuilder) Messages() ([]*CreateMessage, error) {
+	embeds, err := b.BuildAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var msgs []*CreateMessage
+	var total int
+	for _, embed := range embeds {
+		length := embedLength(embed)
+		if len(msgs) == 0 || len(msgs[len(msgs)-1].Embeds) >= MessageEmbedsLimit || total+length > EmbedTotalLimit {
+			msgs = append(msgs, &CreateMessage{})
+			total = 0
+		}
+		msg := msgs[len(msgs)-1]
+		msg.Embeds = append(msg.Embeds, embed)
+		total += length
+	}
+
+	for _, file := range b.files {
+		msg := msgs[0]
+		for _, m := range msgs {
+			if referencesFile(m.Embeds, file.FileName) {
+				msg = m
+				break
+			}
+		}
+		msg.Files = append(msg.Files, file)
+	}
+	return msgs, nil
+}
+
+// embedLength returns the length of the embed that counts towards EmbedTotalLimit.
+func embedLength(embed *Embed) int {
+	return (&payloadValidator{}).embed("", embed)
+}
+
+func referencesFile(embeds []*Embed, fileName string) bool {
+	url := "attachment://" + fileName
+	for _, embed := range embeds {
+		if (embed.Image != nil && embed.Image.URL == url) || (embed.Thumbnail != nil && embed.Thumbnail.URL == url) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitText splits the text into chunks of at most limit characters. Chunks end at the
+// last line break, or otherwise the last space, that fits within the limit.
+func splitText(text string, limit int) []string {
+	var chunks []string
+	for utf8.RuneCountInString(text) > limit {
+		// byte offset of the character at the limit
+		end := 0
+		for i := 0; i < limit; i++ {
+			_, size := utf8.DecodeRuneInString(text[end:])
+			end += size
+		}
+
+		var cut int
+		if next := text[end]; next == '\n' || next == ' ' {
+			cut = end
+		} else if cut = strings.LastIndex(text[:end], "\n"); cut <= 0 {
+			cut = strings.LastIndex(text[:end], " ")
+		}
+		if cut <= 0 {
+			chunks = append(chunks, text[:end])
+			text = text[end:]
+			continue
+		}
+		chunks = append(chunks, text[:cut])
+		text = text[cut+1:]
+	}
+	return append(chunks, text)
+}
@@ -0,0 +1,157 @@
+//go:build !integration
+// +build !integration
+
+package disgord
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEmbedBuilder(t *testing.T) {
+	now := time.Now()
+	msg, err := NewEmbed().
+		Title("title").
+		URL("https://example.com").
+		Description("description").
+		ColorHex("#5865F2").
+		Author("author", "", "").
+		Footer("footer", "").
+		Timestamp(now).
+		ImageFile("graph.png", strings.NewReader("png")).
+		Field("name", "value", true).
+		Message()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(msg.Embeds) != 1 || len(msg.Files) != 1 {
+		t.Fatalf("expected one embed and one file, got %d and %d", len(msg.Embeds), len(msg.Files))
+	}
+	embed := msg.Embeds[0]
+	if embed.Color != 0x5865F2 {
+		t.Errorf("expected color %x, got %x", 0x5865F2, embed.Color)
+	}
+	if embed.Image == nil || embed.Image.URL != "attachment://graph.png" || msg.Files[0].FileName != "graph.png" {
+		t.Errorf("expected the image to reference the attached file, got %+v", embed.Image)
+	}
+	if embed.Footer == nil || embed.Footer.Text != "footer" || !embed.Timestamp.Equal(now) {
+		t.Error("expected footer and timestamp to be set")
+	}
+	if len(embed.Fields) != 1 || !embed.Fields[0].Inline {
+		t.Error("expected a inline field")
+	}
+
+	if color := NewEmbed().ColorRGB(0x58, 0x65, 0xF2).first().Color; color != 0x5865F2 {
+		t.Errorf("expected color %x, got %x", 0x5865F2, color)
+	}
+	if color := NewEmbed().ColorHex("#F0a").first().Color; color != 0xFF00AA {
+		t.Errorf("expected color %x, got %x", 0xFF00AA, color)
+	}
+	for _, hex := range []string{"#GGGGGG", "#FF", "#FFFF", "0xFFFFFFF"} {
+		if _, err = NewEmbed().ColorHex(hex).Build(); !errors.Is(err, ErrIllegalValue) {
+			t.Errorf("expected hex color %q to fail, got %v", hex, err)
+		}
+	}
+}
+
+func TestEmbedBuilder_limits(t *testing.T) {
+	b := NewEmbed()
+	for i := 0; i < EmbedFieldsLimit+1; i++ {
+		b.Field("name", "value", false)
+	}
+	if b.err == nil {
+		t.Fatal("expected the violation to be recorded when the field is added")
+	}
+	_, err := b.Build()
+	var payloadErr *ErrInvalidPayload
+	if !errors.As(err, &payloadErr) || payloadErr.Violations[0].Path != "fields" {
+		t.Fatalf("expected too many fields, got %v", err)
+	}
+}
+
+func TestEmbedBuilder_SplitOverflowFooter(t *testing.T) {
+	footer := strings.Repeat("f", EmbedFooterTextLimit)
+	name, value := strings.Repeat("n", EmbedFieldNameLimit), strings.Repeat("v", EmbedFieldValueLimit)
+
+	before := NewEmbed().SplitOverflow().Footer(footer, "")
+	after := NewEmbed().SplitOverflow()
+	for i := 0; i < 4; i++ {
+		before.Field(name, value, false)
+		after.Field(name, value, false)
+	}
+	after.Footer(footer, "")
+
+	for _, b := range []*EmbedBuilder{before, after} {
+		embeds, err := b.BuildAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(embeds) != 2 || embeds[1].Footer == nil {
+			t.Errorf("expected the footer to move the overflow to a second embed, got %d embeds", len(embeds))
+		}
+	}
+}
+
+func TestEmbedBuilder_SplitOverflow(t *testing.T) {
+	line := strings.Repeat("a", 99) + "\n"
+	b := NewEmbed().SplitOverflow().Title("title").Color(0xFF0000).Footer("footer", "").
+		Description(strings.TrimSpace(strings.Repeat(line, 100))) // 10000 characters
+	for i := 0; i < EmbedFieldsLimit+1; i++ {
+		b.Field("name", strings.Repeat("v", 500), false)
+	}
+
+	if _, err := b.Build(); !errors.Is(err, ErrIllegalValue) {
+		t.Errorf("expected Build to fail for split content, got %v", err)
+	}
+
+	embeds, err := b.BuildAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields int
+	for i, embed := range embeds {
+		if embed.Color != 0xFF0000 {
+			t.Errorf("expected embed %d to keep the color", i)
+		}
+		if embedLength(embed) > EmbedTotalLimit || len(embed.Fields) > EmbedFieldsLimit {
+			t.Errorf("embed %d exceeds the limits", i)
+		}
+		if l := len(embed.Description); l > EmbedDescriptionLimit || (l > 0 && embed.Description[l-1] != 'a') {
+			t.Errorf("expected embed %d to be split at a line break", i)
+		}
+		fields += len(embed.Fields)
+	}
+	if fields != EmbedFieldsLimit+1 {
+		t.Errorf("expected %d fields, got %d", EmbedFieldsLimit+1, fields)
+	}
+	if embeds[0].Title != "title" || embeds[len(embeds)-1].Footer == nil {
+		t.Error("expected the title on the first embed and the footer on the last")
+	}
+
+	msgs, err := b.Messages()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) < 3 {
+		t.Fatalf("expected the embeds to be spread over several messages, got %d", len(msgs))
+	}
+	for i, msg := range msgs {
+		if err = msg.FindErrors(); err != nil {
+			t.Errorf("message %d is invalid: %v", i, err)
+		}
+	}
+}
+
+func TestSplitText(t *testing.T) {
+	chunks := splitText("ab cd ef", 5)
+	if len(chunks) != 2 || chunks[0] != "ab cd" || chunks[1] != "ef" {
+		t.Errorf("unexpected chunks %q", chunks)
+	}
+	chunks = splitText("ææææææ", 4)
+	if len(chunks) != 2 || chunks[0] != "ææææ" || chunks[1] != "ææ" {
+		t.Errorf("unexpected chunks %q", chunks)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/andersfylling/disgord/cdn"
 	"github.com/andersfylling/disgord/internal/endpoint"
 	"github.com/andersfylling/disgord/internal/httd"
 )
@@ -56,6 +57,14 @@ func (e *Emoji) Mention() string {
 	return "<" + prefix + e.Name + ":" + e.ID.String() + ">"
 }
 
+// URL returns a link to the image of a custom emoji. Unicode emojis have no image.
+func (e *Emoji) URL(size int, preferGIF bool) (string, error) {
+	if e.ID.IsZero() {
+		return "", ErrMissingEmojiID
+	}
+	return cdn.CustomEmoji(e.ID, e.Animated).PreferredURL(size, preferGIF)
+}
+
 //////////////////////////////////////////////////////
 //
 // REST Methods
@@ -141,6 +150,10 @@ func (g guildEmojiQueryBuilder) Update(params *UpdateEmoji) (*Emoji, error) {
 		return nil, err
 	}
 
+	if err := g.client.preflight(g.gid, 0, PermissionManageEmojis); err != nil {
+		return nil, err
+	}
+
 	r := g.client.newRESTRequest(&httd.Request{
 		Method:      http.MethodPatch,
 		Ctx:         g.ctx,
@@ -167,6 +180,10 @@ type UpdateEmoji struct {
 // Delete deletes the given emoji. Requires the 'MANAGE_EMOJIS' permission. Returns 204 No Content on
 // success. Fires a Guild Emojis Update Gateway event.
 func (g guildEmojiQueryBuilder) Delete() (err error) {
+	if err = g.client.preflight(g.gid, 0, PermissionManageEmojis); err != nil {
+		return err
+	}
+
 	r := g.client.newRESTRequest(&httd.Request{
 		Method:   http.MethodDelete,
 		Endpoint: endpoint.GuildEmoji(g.gid, g.emojiID),
@@ -0,0 +1,113 @@
+package disgord
+
+import "github.com/andersfylling/disgord/internal/httd"
+
+// APIErrorCode is the JSON error code Discord returns together with a failed REST request, returned by ErrRest.APICode.
+// The codes implement error and can be used as sentinel errors:
+//
+//	if errors.Is(err, disgord.UnknownMessage) {
+//		// the message was already deleted
+//	}
+//
+// https://discord.com/developers/docs/topics/opcodes-and-status-codes#json-json-error-codes
+type APIErrorCode = httd.APIErrorCode
+
+// RESTFieldError describes why a field of a request body was rejected. See ErrRest.Errors.
+type RESTFieldError = httd.FieldError
+
+const (
+	UnknownAccount                       APIErrorCode = 10001
+	UnknownApplication                   APIErrorCode = 10002
+	UnknownChannel                       APIErrorCode = 10003
+	UnknownGuild                         APIErrorCode = 10004
+	UnknownIntegration                   APIErrorCode = 10005
+	UnknownInvite                        APIErrorCode = 10006
+	UnknownMember                        APIErrorCode = 10007
+	UnknownMessage                       APIErrorCode = 10008
+	UnknownPermissionOverwrite           APIErrorCode = 10009
+	UnknownProvider                      APIErrorCode = 10010
+	UnknownRole                          APIErrorCode = 10011
+	UnknownToken                         APIErrorCode = 10012
+	UnknownUser                          APIErrorCode = 10013
+	UnknownEmoji                         APIErrorCode = 10014
+	UnknownWebhook                       APIErrorCode = 10015
+	UnknownWebhookService                APIErrorCode = 10016
+	UnknownSession                       APIErrorCode = 10020
+	UnknownBan                           APIErrorCode = 10026
+	UnknownGuildTemplate                 APIErrorCode = 10057
+	UnknownSticker                       APIErrorCode = 10060
+	UnknownInteraction                   APIErrorCode = 10062
+	UnknownApplicationCommand            APIErrorCode = 10063
+	UnknownApplicationCommandPermissions APIErrorCode = 10066
+	UnknownStageInstance                 APIErrorCode = 10067
+	UnknownGuildWelcomeScreen            APIErrorCode = 10069
+	UnknownGuildScheduledEvent           APIErrorCode = 10070
+	UnknownGuildScheduledEventUser       APIErrorCode = 10071
+
+	BotsCannotUseThisEndpoint  APIErrorCode = 20001
+	OnlyBotsCanUseThisEndpoint APIErrorCode = 20002
+	AnnouncementRateLimited    APIErrorCode = 20022
+	ChannelWriteRateLimited    APIErrorCode = 20028
+
+	MaximumGuildsReached             APIErrorCode = 30001
+	MaximumPinsReached               APIErrorCode = 30003
+	MaximumRolesReached              APIErrorCode = 30005
+	MaximumWebhooksReached           APIErrorCode = 30007
+	MaximumEmojisReached             APIErrorCode = 30008
+	MaximumReactionsReached          APIErrorCode = 30010
+	MaximumChannelsReached           APIErrorCode = 30013
+	MaximumAttachmentsReached        APIErrorCode = 30015
+	MaximumInvitesReached            APIErrorCode = 30016
+	MaximumThreadParticipantsReached APIErrorCode = 30033
+
+	Unauthorized                   APIErrorCode = 40001
+	AccountVerificationRequired    APIErrorCode = 40002
+	RequestEntityTooLarge          APIErrorCode = 40005
+	FeatureTemporarilyDisabled     APIErrorCode = 40006
+	UserBannedFromGuild            APIErrorCode = 40007
+	TargetUserNotConnectedToVoice  APIErrorCode = 40032
+	MessageAlreadyCrossposted      APIErrorCode = 40033
+	InteractionAlreadyAcknowledged APIErrorCode = 40060
+
+	MissingAccess                      APIErrorCode = 50001
+	InvalidAccountType                 APIErrorCode = 50002
+	CannotExecuteActionOnDMChannel     APIErrorCode = 50003
+	GuildWidgetDisabled                APIErrorCode = 50004
+	CannotEditMessageByAnotherUser     APIErrorCode = 50005
+	CannotSendEmptyMessage             APIErrorCode = 50006
+	CannotSendMessagesToThisUser       APIErrorCode = 50007
+	CannotSendMessagesInVoiceChannel   APIErrorCode = 50008
+	ChannelVerificationLevelTooHigh    APIErrorCode = 50009
+	MissingPermissions                 APIErrorCode = 50013
+	InvalidAuthenticationToken         APIErrorCode = 50014
+	InvalidBulkDeleteCount             APIErrorCode = 50016
+	CannotPinMessageInDifferentChannel APIErrorCode = 50019
+	InvalidInviteCode                  APIErrorCode = 50020
+	CannotExecuteActionOnSystemMessage APIErrorCode = 50021
+	CannotExecuteActionOnChannelType   APIErrorCode = 50024
+	InvalidWebhookToken                APIErrorCode = 50027
+	InvalidRole                        APIErrorCode = 50028
+	InvalidRecipients                  APIErrorCode = 50033
+	MessageTooOldToBulkDelete          APIErrorCode = 50034
+	InvalidFormBody                    APIErrorCode = 50035
+	InvalidAPIVersion                  APIErrorCode = 50041
+	FileTooLarge                       APIErrorCode = 50045
+	InvalidFileUploaded                APIErrorCode = 50046
+	CannotDeleteCommunityChannel       APIErrorCode = 50074
+	InvalidStickerSent                 APIErrorCode = 50081
+	ThreadArchived                     APIErrorCode = 50083
+
+	TwoFactorRequired APIErrorCode = 60003
+
+	ReactionBlocked APIErrorCode = 90001
+
+	APIResourceOverloaded APIErrorCode = 130000
+
+	StageAlreadyOpen APIErrorCode = 150006
+
+	CannotReplyWithoutReadMessageHistory    APIErrorCode = 160002
+	ThreadAlreadyCreatedForMessage          APIErrorCode = 160004
+	ThreadLocked                            APIErrorCode = 160005
+	MaximumActiveThreadsReached             APIErrorCode = 160006
+	MaximumActiveAnnouncementThreadsReached APIErrorCode = 160007
+)
@@ -3,6 +3,7 @@ package disgord
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/andersfylling/disgord/internal/disgorderr"
 )
@@ -40,7 +41,12 @@ var ErrMissingScheduledEventName = fmt.Errorf("scheduled event name: %w", ErrMis
 
 var ErrMissingWebhookToken = errors.New("webhook token was not set")
 
+var ErrIteratorDone = errors.New("no more items to iterate")
+var ErrPurgeIncomplete = errors.New("messages could not be deleted")
+var ErrUnsupportedPaginationDirection = errors.New("the endpoint can not be paginated in the given direction")
+
 var ErrIllegalValue = errors.New("illegal value")
+var ErrRoleHierarchy = errors.New("not high enough in the role hierarchy")
 var ErrIllegalScheduledEventPrivacyLevelValue = fmt.Errorf("scheduled event privacy level: %w", ErrIllegalValue)
 
 var ErrMissingTime = fmt.Errorf("time: %w", ErrMissingRequiredField)
@@ -51,3 +57,22 @@ var ErrMissingScheduledEventLocation = fmt.Errorf("scheduled event: %w", ErrMiss
 
 var ErrMissingType = fmt.Errorf("type: %w", ErrMissingRequiredField)
 var ErrMissingScheduledEventEntityType = fmt.Errorf("scheduled event entity: %w", ErrMissingType)
+
+// ErrMissingPermissions is returned when Config.PreflightPermissionChecks is enabled and the bot lacks permissions
+// required by a REST method. The request is not sent.
+type ErrMissingPermissions struct {
+	// Required holds the permissions the bot is missing
+	Required PermissionBit
+	Channel  Snowflake
+	Guild    Snowflake
+}
+
+var _ error = (*ErrMissingPermissions)(nil)
+
+func (e *ErrMissingPermissions) Error() string {
+	msg := "missing permissions " + strings.Join(permissionBitNames(e.Required), ", ")
+	if !e.Channel.IsZero() {
+		msg += " in channel " + e.Channel.String()
+	}
+	return msg + " in guild " + e.Guild.String()
+}
@@ -687,3 +687,11 @@ type GuildScheduledEventUserRemove struct {
 
 	ShardID uint `json:"-"`
 }
+
+// GuildAuditLogEntryCreate a new audit log entry was created in a guild. The entry is not cached.
+type GuildAuditLogEntryCreate struct {
+	*AuditLogEntry
+	GuildID Snowflake `json:"guild_id"`
+
+	ShardID uint `json:"-"`
+}
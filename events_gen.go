@@ -48,6 +48,13 @@ func (h *ChannelUpdate) setShardID(id uint) { h.ShardID = id }
 
 // ---------------------------
 
+// EvtGuildAuditLogEntryCreate Sent when a guild audit log entry is created. Requires the VIEW_AUDIT_LOG permission.
+const EvtGuildAuditLogEntryCreate = event.GuildAuditLogEntryCreate
+
+func (h *GuildAuditLogEntryCreate) setShardID(id uint) { h.ShardID = id }
+
+// ---------------------------
+
 // EvtGuildBanAdd Sent when a user is banned from a guild. The inner payload is a user object, with an extra guild_id key.
 const EvtGuildBanAdd = event.GuildBanAdd
 
@@ -496,6 +503,25 @@ func (shr socketHandlerRegister) ChannelUpdateChan(handler chan *ChannelUpdate,
 	shr.build()
 }
 
+// GuildAuditLogEntryCreate Sent when a guild audit log entry is created. Requires the VIEW_AUDIT_LOG permission.
+func (shr socketHandlerRegister) GuildAuditLogEntryCreate(handler HandlerGuildAuditLogEntryCreate, moreHandlers ...HandlerGuildAuditLogEntryCreate) {
+	shr.evtName = EvtGuildAuditLogEntryCreate
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
+func (shr socketHandlerRegister) GuildAuditLogEntryCreateChan(handler chan *GuildAuditLogEntryCreate, moreHandlers ...chan *GuildAuditLogEntryCreate) {
+	shr.evtName = EvtGuildAuditLogEntryCreate
+	shr.handlers = append(shr.handlers, handler)
+	for _, h := range moreHandlers {
+		shr.handlers = append(shr.handlers, h)
+	}
+	shr.build()
+}
+
 // GuildBanAdd Sent when a user is banned from a guild. The inner payload is a user object, with an extra guild_id key.
 func (shr socketHandlerRegister) GuildBanAdd(handler HandlerGuildBanAdd, moreHandlers ...HandlerGuildBanAdd) {
 	shr.evtName = EvtGuildBanAdd
@@ -1372,6 +1398,8 @@ type SocketHandlerRegistrator interface {
 	ChannelPinsUpdateChan(handler chan *ChannelPinsUpdate, moreHandlers ...chan *ChannelPinsUpdate)
 	ChannelUpdate(handler HandlerChannelUpdate, moreHandlers ...HandlerChannelUpdate)
 	ChannelUpdateChan(handler chan *ChannelUpdate, moreHandlers ...chan *ChannelUpdate)
+	GuildAuditLogEntryCreate(handler HandlerGuildAuditLogEntryCreate, moreHandlers ...HandlerGuildAuditLogEntryCreate)
+	GuildAuditLogEntryCreateChan(handler chan *GuildAuditLogEntryCreate, moreHandlers ...chan *GuildAuditLogEntryCreate)
 	GuildBanAdd(handler HandlerGuildBanAdd, moreHandlers ...HandlerGuildBanAdd)
 	GuildBanAddChan(handler chan *GuildBanAdd, moreHandlers ...chan *GuildBanAdd)
 	GuildBanRemove(handler HandlerGuildBanRemove, moreHandlers ...HandlerGuildBanRemove)
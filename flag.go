@@ -1,5 +1,7 @@
 package disgord
 
+import "github.com/andersfylling/disgord/internal/httd"
+
 type Flag uint32
 
 func (f Flag) Ignorecache() bool {
@@ -31,8 +33,26 @@ const (
 	// ordering
 	OrderAscending // default when sorting
 	OrderDescending
+
+	// PriorityHigh and PriorityLow decide the order of requests waiting for the same rate limit bucket.
+	// Requests of low priority are served eventually, even when requests of higher priority keep coming.
+	// Interaction responses are of high priority by default.
+	PriorityHigh
+	PriorityLow
 )
 
+// priority returns the rate limit priority given by the flags, or the fallback.
+func (f Flag) priority(fallback httd.Priority) httd.Priority {
+	switch {
+	case (f & PriorityHigh) > 0:
+		return httd.PriorityHigh
+	case (f & PriorityLow) > 0:
+		return httd.PriorityLow
+	default:
+		return fallback
+	}
+}
+
 func mergeFlags(flags []Flag) (f Flag) {
 	for i := range flags {
 		f |= flags[i]
@@ -0,0 +1,129 @@
+package disgord
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// zeroWidthSpace is inserted to break markup without changing how the text looks.
+const zeroWidthSpace = "\u200b"
+
+// TimestampStyle decides how the Discord client displays a timestamp, in the locale of the reader.
+//
+// https://discord.com/developers/docs/reference#message-formatting-timestamp-styles
+type TimestampStyle string
+
+const (
+	TimestampDefault       TimestampStyle = ""
+	TimestampShortTime     TimestampStyle = "t" // 16:20
+	TimestampLongTime      TimestampStyle = "T" // 16:20:30
+	TimestampShortDate     TimestampStyle = "d" // 20/04/2021
+	TimestampLongDate      TimestampStyle = "D" // 20 April 2021
+	TimestampShortDateTime TimestampStyle = "f" // 20 April 2021 16:20
+	TimestampLongDateTime  TimestampStyle = "F" // Tuesday, 20 April 2021 16:20
+	TimestampRelative      TimestampStyle = "R" // 2 months ago
+)
+
+// FormatTimestamp creates the markup for a timestamp, such as <t:1618953630:R>. The default style
+// shows a short date and time.
+func FormatTimestamp(t time.Time, style TimestampStyle) string {
+	markup := "<t:" + strconv.FormatInt(t.Unix(), 10)
+	if style != TimestampDefault {
+		markup += ":" + string(style)
+	}
+	return markup + ">"
+}
+
+// FormatCommandMention creates a clickable mention of a slash command, such as </config set:1234>. The
+// subcommand group and subcommand names are optional.
+func FormatCommandMention(commandID Snowflake, name string, subcommands ...string) string {
+	parts := append([]string{name}, subcommands...)
+	return "</" + strings.Join(parts, " ") + ":" + commandID.String() + ">"
+}
+
+// FormatCodeBlock wraps the code in a code block highlighted for the given language, which may be empty.
+// Backticks in the code are broken up so they can not end the block early.
+func FormatCodeBlock(language, code string) string {
+	code = separateBackticks(code)
+	return "```" + language + "\n" + code + "\n```"
+}
+
+// FormatInlineCode wraps the text in an inline code span.
+func FormatInlineCode(code string) string {
+	if !strings.Contains(code, "`") {
+		return "`" + code + "`"
+	}
+	// a span started by two backticks can hold single backticks
+	return "`` " + separateBackticks(code) + " ``"
+}
+
+// separateBackticks places a zero width space between every pair of adjacent backticks, such that the
+// text holds no run of backticks that could end a code span or block.
+func separateBackticks(code string) string {
+	if !strings.Contains(code, "``") {
+		return code
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(code))
+	for i := 0; i < len(code); i++ {
+		if i > 0 && code[i] == '`' && code[i-1] == '`' {
+			sb.WriteString(zeroWidthSpace)
+		}
+		sb.WriteByte(code[i])
+	}
+	return sb.String()
+}
+
+// EscapeMarkdown escapes the markdown of user supplied text, so it is shown as written. This covers
+// bold, italics, underline, strikethrough, spoilers, code, masked links, and quotes, headers and
+// lists at the start of a line. Mentions are not affected, see EscapeMentions.
+func EscapeMarkdown(text string) string {
+	var sb strings.Builder
+	sb.Grow(len(text))
+
+	lineStart := true
+	for _, r := range text {
+		switch r {
+		case '\\', '*', '_', '~', '`', '|', '[', ']':
+			sb.WriteRune('\\')
+		case '>', '#', '-':
+			if lineStart {
+				sb.WriteRune('\\')
+			}
+		}
+		sb.WriteRune(r)
+
+		switch r {
+		case '\n':
+			lineStart = true
+		case ' ', '\t':
+			// indented markup is still rendered
+		default:
+			lineStart = false
+		}
+	}
+	return sb.String()
+}
+
+// EscapeMentions breaks @everyone, @here, and user, role and channel mentions in user supplied text, so they
+// neither notify anyone nor render as mentions. The text looks the same to readers.
+//
+// Use it together with an AllowedMentions that permits only the mentions you add yourself, such as
+// &AllowedMentions{Parse: []string{}, Users: []Snowflake{authorID}}.
+func EscapeMentions(text string) string {
+	return mentionEscaper.Replace(text)
+}
+
+var mentionEscaper = strings.NewReplacer(
+	"@everyone", "@"+zeroWidthSpace+"everyone",
+	"@here", "@"+zeroWidthSpace+"here",
+	"<@", "<@"+zeroWidthSpace,
+	"<#", "<#"+zeroWidthSpace,
+)
+
+// EscapeUserInput escapes both markdown and mentions. See EscapeMarkdown and EscapeMentions.
+func EscapeUserInput(text string) string {
+	return EscapeMentions(EscapeMarkdown(text))
+}
@@ -0,0 +1,92 @@
+//go:build !integration
+// +build !integration
+
+package disgord
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatTimestamp(t *testing.T) {
+	ts := time.Unix(1618953630, 0)
+	testCases := map[TimestampStyle]string{
+		TimestampDefault:       "<t:1618953630>",
+		TimestampShortTime:     "<t:1618953630:t>",
+		TimestampLongTime:      "<t:1618953630:T>",
+		TimestampShortDate:     "<t:1618953630:d>",
+		TimestampLongDate:      "<t:1618953630:D>",
+		TimestampShortDateTime: "<t:1618953630:f>",
+		TimestampLongDateTime:  "<t:1618953630:F>",
+		TimestampRelative:      "<t:1618953630:R>",
+	}
+	for style, expected := range testCases {
+		if got := FormatTimestamp(ts, style); got != expected {
+			t.Errorf("expected %q, got %q", expected, got)
+		}
+	}
+}
+
+func TestFormatCommandMention(t *testing.T) {
+	if got := FormatCommandMention(1234, "ping"); got != "</ping:1234>" {
+		t.Errorf("unexpected mention %q", got)
+	}
+	if got := FormatCommandMention(1234, "config", "roles", "add"); got != "</config roles add:1234>" {
+		t.Errorf("unexpected mention %q", got)
+	}
+}
+
+func TestFormatCode(t *testing.T) {
+	if got := FormatCodeBlock("go", "a := 1"); got != "```go\na := 1\n```" {
+		t.Errorf("unexpected code block %q", got)
+	}
+	for _, code := range []string{"```\nend", "````", "x```y"} {
+		got := FormatCodeBlock("", code)
+		if inner := got[4 : len(got)-4]; strings.Contains(inner, "``") || strings.Replace(inner, zeroWidthSpace, "", -1) != code {
+			t.Errorf("expected the backticks of %q to be broken up, got %q", code, got)
+		}
+	}
+	if got := FormatInlineCode("a"); got != "`a`" {
+		t.Errorf("unexpected inline code %q", got)
+	}
+	if got := FormatInlineCode("a`b"); got != "`` a`b ``" {
+		t.Errorf("unexpected inline code %q", got)
+	}
+	for _, code := range []string{"x```y", "````"} {
+		got := FormatInlineCode(code)
+		if inner := got[3 : len(got)-3]; strings.Contains(inner, "``") || strings.Replace(inner, zeroWidthSpace, "", -1) != code {
+			t.Errorf("expected the backticks of %q to be broken up, got %q", code, got)
+		}
+	}
+}
+
+func TestEscapeMarkdown(t *testing.T) {
+	testCases := []struct {
+		text     string
+		expected string
+	}{
+		{"**bold** _it_ ~~no~~ ||spoiler||", `\*\*bold\*\* \_it\_ \~\~no\~\~ \|\|spoiler\|\|`},
+		{"`code` [link](https://example.com)", "\\`code\\` \\[link\\](https://example.com)"},
+		{"> quote\n  # header\n- item", "\\> quote\n  \\# header\n\\- item"},
+		{"a - b > c # d", "a - b > c # d"},
+		{`C:\path`, `C:\\path`},
+	}
+	for _, tc := range testCases {
+		if got := EscapeMarkdown(tc.text); got != tc.expected {
+			t.Errorf("expected %q, got %q", tc.expected, got)
+		}
+	}
+}
+
+func TestEscapeMentions(t *testing.T) {
+	got := EscapeMentions("@everyone @here <@123> <@!123> <@&456> <#789> user@example.com")
+	expected := "@\u200beveryone @\u200bhere <@\u200b123> <@\u200b!123> <@\u200b&456> <#\u200b789> user@example.com"
+	if got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+
+	if got = EscapeUserInput("**@everyone**"); got != `\*\*@`+zeroWidthSpace+`everyone\*\*` {
+		t.Errorf("unexpected escaped input %q", got)
+	}
+}
@@ -8,6 +8,7 @@ require (
 	go.uber.org/atomic v1.10.0
 	golang.org/x/crypto v0.1.0
 	golang.org/x/net v0.1.0
+	golang.org/x/sys v0.1.0
 	golang.org/x/xerrors v0.0.0-20200804184101-5ec99f83aff1 // indirect
 	k8s.io/gengo v0.0.0-20220307231824-4627b89bbf1b
 	nhooyr.io/websocket v1.8.7
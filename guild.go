@@ -10,6 +10,7 @@ import (
 
 	"github.com/andersfylling/disgord/json"
 
+	"github.com/andersfylling/disgord/cdn"
 	"github.com/andersfylling/disgord/internal/endpoint"
 	"github.com/andersfylling/disgord/internal/httd"
 )
@@ -111,8 +112,11 @@ const (
 	PermissionManageServer
 	PermissionAddReactions
 	PermissionViewAuditLogs
-	_
-	PermissionViewChannel
+
+	// PermissionViewChannel is the VIEW_CHANNEL permission, 1 << 10, which was previously named read messages.
+	// It used to be declared as 1 << 9, which is the STREAM permission. Values stored or compared using the
+	// old constant must be updated.
+	PermissionViewChannel = PermissionReadMessages
 
 	PermissionTextAll = PermissionReadMessages |
 		PermissionSendMessages |
@@ -169,6 +173,70 @@ const (
 	PermissionRequestToSpeak PermissionBit = 1 << 32
 )
 
+// permissionNames holds the Discord names of the permission bits, in bit order.
+var permissionNames = []struct {
+	bit  PermissionBit
+	name string
+}{
+	{PermissionCreateInstantInvite, "CREATE_INSTANT_INVITE"},
+	{PermissionKickMembers, "KICK_MEMBERS"},
+	{PermissionBanMembers, "BAN_MEMBERS"},
+	{PermissionAdministrator, "ADMINISTRATOR"},
+	{PermissionManageChannels, "MANAGE_CHANNELS"},
+	{PermissionManageServer, "MANAGE_GUILD"},
+	{PermissionAddReactions, "ADD_REACTIONS"},
+	{PermissionViewAuditLogs, "VIEW_AUDIT_LOG"},
+	{PermissionVoicePrioritySpeaker, "PRIORITY_SPEAKER"},
+	{PermissionVoiceStream, "STREAM"},
+	{PermissionViewChannel, "VIEW_CHANNEL"},
+	{PermissionSendMessages, "SEND_MESSAGES"},
+	{PermissionSendTTSMessages, "SEND_TTS_MESSAGES"},
+	{PermissionManageMessages, "MANAGE_MESSAGES"},
+	{PermissionEmbedLinks, "EMBED_LINKS"},
+	{PermissionAttachFiles, "ATTACH_FILES"},
+	{PermissionReadMessageHistory, "READ_MESSAGE_HISTORY"},
+	{PermissionMentionEveryone, "MENTION_EVERYONE"},
+	{PermissionUseExternalEmojis, "USE_EXTERNAL_EMOJIS"},
+	{PermissionViewGuildInsights, "VIEW_GUILD_INSIGHTS"},
+	{PermissionVoiceConnect, "CONNECT"},
+	{PermissionVoiceSpeak, "SPEAK"},
+	{PermissionVoiceMuteMembers, "MUTE_MEMBERS"},
+	{PermissionVoiceDeafenMembers, "DEAFEN_MEMBERS"},
+	{PermissionVoiceMoveMembers, "MOVE_MEMBERS"},
+	{PermissionVoiceUseVAD, "USE_VAD"},
+	{PermissionChangeNickname, "CHANGE_NICKNAME"},
+	{PermissionManageNicknames, "MANAGE_NICKNAMES"},
+	{PermissionManageRoles, "MANAGE_ROLES"},
+	{PermissionManageWebhooks, "MANAGE_WEBHOOKS"},
+	{PermissionManageEmojis, "MANAGE_EMOJIS_AND_STICKERS"},
+	{PermissionUseSlashCommands, "USE_APPLICATION_COMMANDS"},
+	{PermissionRequestToSpeak, "REQUEST_TO_SPEAK"},
+	{PermissionManageEvents, "MANAGE_EVENTS"},
+	{PermissionManageThreads, "MANAGE_THREADS"},
+	{PermissionCreatePublicThreads, "CREATE_PUBLIC_THREADS"},
+	{PermissionCreatePrivateThreads, "CREATE_PRIVATE_THREADS"},
+	{PermissionUseExternalStickers, "USE_EXTERNAL_STICKERS"},
+	{PermissionSendMessagesInThreads, "SEND_MESSAGES_IN_THREADS"},
+	{PermissionUseEmbeddedActivites, "USE_EMBEDDED_ACTIVITIES"},
+	{PermissionTimeoutMembers, "MODERATE_MEMBERS"},
+}
+
+// permissionBitNames returns the Discord names of the bits set in b. Unknown bits are
+// appended as a single decimal value.
+func permissionBitNames(b PermissionBit) []string {
+	var names []string
+	for _, p := range permissionNames {
+		if b&p.bit != 0 {
+			names = append(names, p.name)
+			b &^= p.bit
+		}
+	}
+	if b != 0 {
+		names = append(names, strconv.FormatUint(uint64(b), 10))
+	}
+	return names
+}
+
 // GuildUnavailable is a partial Guild object.
 type GuildUnavailable struct {
 	ID          Snowflake `json:"id"`
@@ -245,6 +313,26 @@ func (g *Guild) updateInternals() {
 	}
 }
 
+// IconURL returns a link to the guild icon with the given size.
+func (g *Guild) IconURL(size int, preferGIF bool) (string, error) {
+	return cdn.GuildIcon(g.ID, g.Icon).PreferredURL(size, preferGIF)
+}
+
+// BannerURL returns a link to the guild banner with the given size.
+func (g *Guild) BannerURL(size int, preferGIF bool) (string, error) {
+	return cdn.GuildBanner(g.ID, g.Banner).PreferredURL(size, preferGIF)
+}
+
+// SplashURL returns a link to the guild invite splash with the given size.
+func (g *Guild) SplashURL(size int) (string, error) {
+	return cdn.GuildSplash(g.ID, g.Splash).PreferredURL(size, false)
+}
+
+// DiscoverySplashURL returns a link to the guild discovery splash with the given size.
+func (g *Guild) DiscoverySplashURL(size int) (string, error) {
+	return cdn.GuildDiscoverySplash(g.ID, g.DiscoverySplash).PreferredURL(size, false)
+}
+
 // GetMemberWithHighestSnowflake finds the member with the highest snowflake value.
 func (g *Guild) GetMemberWithHighestSnowflake() *Member {
 	if len(g.Members) == 0 {
@@ -547,6 +635,7 @@ type Member struct {
 	GuildID                    Snowflake   `json:"guild_id,omitempty"`
 	User                       *User       `json:"user"`
 	Nick                       string      `json:"nick,omitempty"`
+	Avatar                     string      `json:"avatar,omitempty"` // guild specific avatar hash
 	Roles                      []Snowflake `json:"roles"`
 	JoinedAt                   Time        `json:"joined_at,omitempty"`
 	PremiumSince               Time        `json:"premium_since,omitempty"`
@@ -593,7 +682,8 @@ func (m *Member) UpdateNick(ctx context.Context, client GuildQueryBuilderCaller,
 	return err
 }
 
-// GetPermissions populates a uint64 with all the permission flags
+// GetPermissions populates a uint64 with all the permission flags. The @everyone role, administrator and
+// owner rules are not applied, see ComputeBasePermissions or Cache.GetMemberPermissions.
 func (m *Member) GetPermissions(ctx context.Context, s GuildQueryBuilderCaller) (permissions PermissionBit, err error) {
 	// TODO: Don't deep copy channels for this in the future!
 	roles, err := s.Guild(m.GuildID).WithContext(ctx).GetRoles()
@@ -619,6 +709,22 @@ func (m *Member) GetPermissions(ctx context.Context, s GuildQueryBuilderCaller)
 	return permissions, nil
 }
 
+// AvatarURL returns a link to the guild specific avatar of the member. Members without a guild avatar
+// gets a link to their user avatar instead, which requires the user object to be set.
+func (m *Member) AvatarURL(size int, preferGIF bool) (string, error) {
+	userID := m.UserID
+	if userID.IsZero() && m.User != nil {
+		userID = m.User.ID
+	}
+	if m.Avatar != "" {
+		return cdn.GuildMemberAvatar(m.GuildID, userID, m.Avatar).PreferredURL(size, preferGIF)
+	}
+	if m.User == nil {
+		return "", errors.New("member has no guild avatar and the user object is missing")
+	}
+	return m.User.AvatarURL(size, preferGIF)
+}
+
 // GetUser tries to ensure that you get a user object and not a nil. The user can be nil if the guild
 // was fetched from the cache.
 func (m *Member) GetUser(ctx context.Context, session Session) (usr *User, err error) {
@@ -737,6 +843,9 @@ type GuildQueryBuilder interface {
 	DisconnectVoiceParticipant(userID Snowflake) error
 	SetCurrentUserNick(nick string) (newNick string, err error)
 	GetBans() ([]*Ban, error)
+
+	// IterateBans walks the ban list of the guild, which is ordered by user ID. The default direction is forward.
+	IterateBans(params *IterateBans) *BanIterator
 	GetBan(userID Snowflake) (*Ban, error)
 	UnbanUser(userID Snowflake, reason string) error
 
@@ -764,6 +873,10 @@ type GuildQueryBuilder interface {
 	GetVanityURL() (*PartialInvite, error)
 	GetAuditLogs(logs *GetAuditLogs) (*AuditLog, error)
 
+	// IterateAuditLogs walks the audit log using the REST API. It can backfill older entries, or follow
+	// new entries by polling when the GUILD_AUDIT_LOG_ENTRY_CREATE event is not an option.
+	IterateAuditLogs(params *IterateAuditLogs) *AuditLogIterator
+
 	VoiceChannel(channelID Snowflake) VoiceChannelQueryBuilder
 
 	// GetEmojis
@@ -847,6 +960,10 @@ func (g guildQueryBuilder) Update(params *UpdateGuild) (*Guild, error) {
 		return nil, err
 	}
 
+	if err := g.client.preflight(g.gid, 0, PermissionManageServer); err != nil {
+		return nil, err
+	}
+
 	r := g.client.newRESTRequest(&httd.Request{
 		Method:      http.MethodPatch,
 		Ctx:         g.ctx,
@@ -944,6 +1061,10 @@ func (g guildQueryBuilder) CreateChannel(name string, params *CreateGuildChannel
 		params.Name = name
 	}
 
+	if err := g.client.preflight(g.gid, 0, PermissionManageChannels); err != nil {
+		return nil, err
+	}
+
 	r := g.client.newRESTRequest(&httd.Request{
 		Method:      http.MethodPost,
 		Ctx:         g.ctx,
@@ -970,6 +1091,11 @@ func (g guildQueryBuilder) UpdateChannelPositions(params []UpdateGuildChannelPos
 			break
 		}
 	}
+
+	if err := g.client.preflight(g.gid, 0, PermissionManageChannels); err != nil {
+		return err
+	}
+
 	r := g.client.newRESTRequest(&httd.Request{
 		Method:      http.MethodPatch,
 		Ctx:         g.ctx,
@@ -1113,10 +1239,28 @@ func (g guildQueryBuilder) SetCurrentUserNick(nick string) (newNick string, err
 	return getNickName(r.Execute)
 }
 
+// GetBans https://discord.com/developers/docs/resources/guild#get-guild-bans-query-string-params
+type GetBans struct {
+	Before Snowflake `urlparam:"before,omitempty"`
+	After  Snowflake `urlparam:"after,omitempty"`
+	Limit  int       `urlparam:"limit,omitempty"`
+}
+
+var _ URLQueryStringer = (*GetBans)(nil)
+
 // GetBans returns an array of ban objects for the Users banned from this guild. Requires the 'BAN_MEMBERS' permission.
 func (g guildQueryBuilder) GetBans() ([]*Ban, error) {
+	return g.getBans(nil)
+}
+
+func (g guildQueryBuilder) getBans(params *GetBans) ([]*Ban, error) {
+	var query string
+	if params != nil {
+		query += params.URLQueryString()
+	}
+
 	r := g.client.newRESTRequest(&httd.Request{
-		Endpoint: endpoint.GuildBans(g.gid),
+		Endpoint: endpoint.GuildBans(g.gid) + query,
 		Ctx:      g.ctx,
 	}, g.flags)
 	r.factory = func() interface{} {
@@ -1136,6 +1280,65 @@ func (g guildQueryBuilder) GetBans() ([]*Ban, error) {
 	return nil, errors.New("unable to cast guild slice")
 }
 
+// IterateBans configures a BanIterator.
+type IterateBans struct {
+	Pagination
+
+	// Until stops the iteration when it returns true. The ban is not returned.
+	Until func(ban *Ban) bool
+}
+
+// BanIterator walks the ban list of a guild. See GuildQueryBuilder.IterateBans.
+type BanIterator struct {
+	it *cursorIterator
+}
+
+// Next returns the next ban, or ErrIteratorDone after the last ban.
+func (b *BanIterator) Next(ctx context.Context) (*Ban, error) {
+	item, err := b.it.next(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return item.(*Ban), nil
+}
+
+// IterateBans creates a iterator for the guild bans. Requires the 'BAN_MEMBERS' permission.
+func (g guildQueryBuilder) IterateBans(params *IterateBans) *BanIterator {
+	const pageSizeMax = 1000
+	if params == nil {
+		params = &IterateBans{}
+	}
+
+	fetch := func(ctx context.Context, before, after Snowflake, limit int) ([]interface{}, error) {
+		q := g
+		q.ctx = ctx
+		bans, err := q.getBans(&GetBans{Before: before, After: after, Limit: limit})
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, len(bans))
+		for i := range bans {
+			items[i] = bans[i]
+		}
+		return items, nil
+	}
+	id := func(item interface{}) Snowflake {
+		if ban := item.(*Ban); ban.User != nil {
+			return ban.User.ID
+		}
+		return 0
+	}
+
+	it := newCursorIterator(params.Pagination, PaginateForward, pageSizeMax, fetch, id)
+	if params.Until != nil {
+		until := params.Until
+		it.until = func(item interface{}) bool {
+			return until(item.(*Ban))
+		}
+	}
+	return &BanIterator{it: it}
+}
+
 // GetBan Returns a ban object for the given user or a 404 not found if the ban cannot be found.
 // Requires the 'BAN_MEMBERS' permission.
 func (g guildQueryBuilder) GetBan(userID Snowflake) (*Ban, error) {
@@ -1153,6 +1356,10 @@ func (g guildQueryBuilder) GetBan(userID Snowflake) (*Ban, error) {
 // UnbanUser Remove the ban for a user. Requires the 'BAN_MEMBERS' permissions.
 // Returns a 204 empty response on success. Fires a Guild Ban Remove Gateway event.
 func (g guildQueryBuilder) UnbanUser(userID Snowflake, reason string) error {
+	if err := g.client.preflight(g.gid, 0, PermissionBanMembers); err != nil {
+		return err
+	}
+
 	r := g.client.newRESTRequest(&httd.Request{
 		Method:   http.MethodDelete,
 		Endpoint: endpoint.GuildBan(g.gid, userID),
@@ -1209,6 +1416,10 @@ type CreateGuildRole struct {
 // CreateRole Create a new role for the guild. Requires the 'MANAGE_ROLES' permission.
 // Returns the new role object on success. Fires a Guild Role Create Gateway event.
 func (g guildQueryBuilder) CreateRole(params *CreateGuildRole) (*Role, error) {
+	if err := g.client.preflight(g.gid, 0, PermissionManageRoles); err != nil {
+		return nil, err
+	}
+
 	r := g.client.newRESTRequest(&httd.Request{
 		Method:      http.MethodPost,
 		Ctx:         g.ctx,
@@ -1236,6 +1447,10 @@ func (g guildQueryBuilder) UpdateRolePositions(params []UpdateGuildRolePositions
 		}
 	}
 
+	if err := g.client.preflight(g.gid, 0, PermissionManageRoles); err != nil {
+		return nil, err
+	}
+
 	r := g.client.newRESTRequest(&httd.Request{
 		Method:      http.MethodPatch,
 		Ctx:         g.ctx,
@@ -1298,6 +1513,10 @@ func (g guildQueryBuilder) PruneMembers(params *PruneMembers) (pruned int, err e
 		Pruned int `json:"pruned"`
 	}
 
+	if err = g.client.preflight(g.gid, 0, PermissionKickMembers); err != nil {
+		return 0, err
+	}
+
 	r := g.client.newRESTRequest(&httd.Request{
 		Method:   http.MethodPost,
 		Endpoint: endpoint.GuildPrune(g.gid),
@@ -1373,6 +1592,10 @@ func (g guildQueryBuilder) GetIntegrations() ([]*Integration, error) {
 // Requires the 'MANAGE_GUILD' permission. Returns a 204 empty response on success.
 // Fires a Guild Integrations Update Gateway event.
 func (g guildQueryBuilder) CreateIntegration(params *CreateGuildIntegration) error {
+	if err := g.client.preflight(g.gid, 0, PermissionManageServer); err != nil {
+		return err
+	}
+
 	r := g.client.newRESTRequest(&httd.Request{
 		Method:      http.MethodPost,
 		Ctx:         g.ctx,
@@ -1389,6 +1612,10 @@ func (g guildQueryBuilder) CreateIntegration(params *CreateGuildIntegration) err
 // Requires the 'MANAGE_GUILD' permission. Returns a 204 empty response on success.
 // Fires a Guild Integrations Update Gateway event.
 func (g guildQueryBuilder) UpdateIntegration(integrationID Snowflake, params *UpdateGuildIntegration) error {
+	if err := g.client.preflight(g.gid, 0, PermissionManageServer); err != nil {
+		return err
+	}
+
 	r := g.client.newRESTRequest(&httd.Request{
 		Method:      http.MethodPatch,
 		Ctx:         g.ctx,
@@ -1405,6 +1632,10 @@ func (g guildQueryBuilder) UpdateIntegration(integrationID Snowflake, params *Up
 // Requires the 'MANAGE_GUILD' permission. Returns a 204 empty response on success.
 // Fires a Guild Integrations Update Gateway event.
 func (g guildQueryBuilder) DeleteIntegration(integrationID Snowflake) error {
+	if err := g.client.preflight(g.gid, 0, PermissionManageServer); err != nil {
+		return err
+	}
+
 	r := g.client.newRESTRequest(&httd.Request{
 		Method:      http.MethodDelete,
 		Ctx:         g.ctx,
@@ -1419,6 +1650,10 @@ func (g guildQueryBuilder) DeleteIntegration(integrationID Snowflake) error {
 // SyncIntegration Sync an integration. Requires the 'MANAGE_GUILD' permission.
 // Returns a 204 empty response on success.
 func (g guildQueryBuilder) SyncIntegration(integrationID Snowflake) error {
+	if err := g.client.preflight(g.gid, 0, PermissionManageServer); err != nil {
+		return err
+	}
+
 	r := g.client.newRESTRequest(&httd.Request{
 		Method:   http.MethodPost,
 		Endpoint: endpoint.GuildIntegrationSync(g.gid, integrationID),
@@ -1449,6 +1684,10 @@ func (g guildQueryBuilder) UpdateWidget(params *UpdateGuildWidget) (*GuildWidget
 		return nil, err
 	}
 
+	if err := g.client.preflight(g.gid, 0, PermissionManageServer); err != nil {
+		return nil, err
+	}
+
 	r := g.client.newRESTRequest(&httd.Request{
 		Method:      http.MethodPatch,
 		Ctx:         g.ctx,
@@ -1504,9 +1743,10 @@ func (g guildQueryBuilder) GetAuditLogs(params *GetAuditLogs) (*AuditLog, error)
 }
 
 type GetAuditLogs struct {
-	UserID     Snowflake `urlparam:"user_id"`
-	ActionType int       `urlparam:"action_type"`
+	UserID     Snowflake `urlparam:"user_id,omitempty"`
+	ActionType int       `urlparam:"action_type,omitempty"`
 	Before     Snowflake `urlparam:"before,omitempty"`
+	After      Snowflake `urlparam:"after,omitempty"`
 	Limit      int       `urlparam:"limit,omitempty"`
 }
 
@@ -1566,6 +1806,10 @@ func (g guildQueryBuilder) CreateEmoji(params *CreateGuildEmoji) (*Emoji, error)
 		return nil, errors.New("image string must be base64 encoded with base64 prefix")
 	}
 
+	if err := g.client.preflight(g.gid, 0, PermissionManageEmojis); err != nil {
+		return nil, err
+	}
+
 	r := g.client.newRESTRequest(&httd.Request{
 		Method:      http.MethodPost,
 		Ctx:         g.ctx,
@@ -1832,6 +2076,10 @@ func (g guildQueryBuilder) CreateScheduledEvent(params *CreateScheduledEvent) (*
 		return nil, err
 	}
 
+	if err := g.client.preflight(g.gid, 0, PermissionManageEvents); err != nil {
+		return nil, err
+	}
+
 	r := g.client.newRESTRequest(&httd.Request{
 		Method:      http.MethodPost,
 		Ctx:         g.ctx,
@@ -35,6 +35,9 @@ type GuildScheduledEventQueryBuilder interface {
 	Delete() error
 
 	GetMembers(params *GetScheduledEventMembers) ([]*GuildScheduledEventUsers, error)
+
+	// IterateMembers walks the users subscribed to the event, ordered by user ID. The default direction is forward.
+	IterateMembers(params *IterateScheduledEventMembers) *ScheduledEventMemberIterator
 }
 
 type guildScheduledEventQueryBuilder struct {
@@ -272,3 +275,75 @@ func (gse guildScheduledEventQueryBuilder) GetMembers(params *GetScheduledEventM
 
 	return getScheduledEventUsers(r.Execute)
 }
+
+// IterateScheduledEventMembers configures a ScheduledEventMemberIterator.
+type IterateScheduledEventMembers struct {
+	Pagination
+	WithMember bool
+
+	// Until stops the iteration when it returns true. The user is not returned.
+	Until func(user *GuildScheduledEventUsers) bool
+}
+
+// ScheduledEventMemberIterator walks the users subscribed to a scheduled event.
+// See GuildScheduledEventQueryBuilder.IterateMembers.
+type ScheduledEventMemberIterator struct {
+	it *cursorIterator
+}
+
+// Next returns the next user, or ErrIteratorDone after the last user.
+func (s *ScheduledEventMemberIterator) Next(ctx context.Context) (*GuildScheduledEventUsers, error) {
+	for {
+		item, err := s.it.next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if user := item.(*GuildScheduledEventUsers); user != nil {
+			return user, nil
+		}
+	}
+}
+
+func (gse guildScheduledEventQueryBuilder) IterateMembers(params *IterateScheduledEventMembers) *ScheduledEventMemberIterator {
+	const pageSizeMax = 100
+	if params == nil {
+		params = &IterateScheduledEventMembers{}
+	}
+
+	fetch := func(ctx context.Context, before, after Snowflake, limit int) ([]interface{}, error) {
+		users, err := gse.WithContext(ctx).GetMembers(&GetScheduledEventMembers{
+			Limit:      uint32(limit),
+			WithMember: params.WithMember,
+			Before:     before,
+			After:      after,
+		})
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, len(users))
+		for i := range users {
+			items[i] = users[i]
+		}
+		return items, nil
+	}
+	id := func(item interface{}) Snowflake {
+		user := item.(*GuildScheduledEventUsers)
+		if user == nil {
+			return 0
+		}
+		if user.User.ID.IsZero() && user.Member.User != nil {
+			return user.Member.User.ID
+		}
+		return user.User.ID
+	}
+
+	it := newCursorIterator(params.Pagination, PaginateForward, pageSizeMax, fetch, id)
+	if params.Until != nil {
+		until := params.Until
+		it.until = func(item interface{}) bool {
+			user := item.(*GuildScheduledEventUsers)
+			return user != nil && until(user)
+		}
+	}
+	return &ScheduledEventMemberIterator{it: it}
+}
@@ -119,6 +119,10 @@ func (a *AuditLog) copyOverTo(other interface{}) error {
 	for i := 0; i < len(a.AuditLogEntries); i++ {
 		dest.AuditLogEntries[i] = DeepCopy(a.AuditLogEntries[i]).(*AuditLogEntry)
 	}
+	dest.Threads = make([]*Channel, len(a.Threads))
+	for i := 0; i < len(a.Threads); i++ {
+		dest.Threads[i] = DeepCopy(a.Threads[i]).(*Channel)
+	}
 	dest.Users = make([]*User, len(a.Users))
 	for i := 0; i < len(a.Users); i++ {
 		dest.Users[i] = DeepCopy(a.Users[i]).(*User)
@@ -522,6 +526,7 @@ func (m *Member) copyOverTo(other interface{}) error {
 	if dest, valid = other.(*Member); !valid {
 		return newErrorUnsupportedType("argument given is not a *Member type")
 	}
+	dest.Avatar = m.Avatar
 	dest.CommunicationDisabledUntil = m.CommunicationDisabledUntil
 	dest.Deaf = m.Deaf
 	dest.GuildID = m.GuildID
@@ -685,6 +690,7 @@ func (r *Role) copyOverTo(other interface{}) error {
 	dest.Color = r.Color
 	dest.guildID = r.guildID
 	dest.Hoist = r.Hoist
+	dest.Icon = r.Icon
 	dest.ID = r.ID
 	dest.Managed = r.Managed
 	dest.Mentionable = r.Mentionable
@@ -97,6 +97,7 @@ func (g *Guild) reset() {
 }
 
 func (m *Member) reset() {
+	m.Avatar = ""
 	m.CommunicationDisabledUntil = Time{}
 	m.Deaf = false
 	m.GuildID = 0
@@ -171,6 +172,7 @@ func (r *Role) reset() {
 	r.Color = 0
 	r.guildID = 0
 	r.Hoist = false
+	r.Icon = ""
 	r.ID = 0
 	r.Managed = false
 	r.Mentionable = false
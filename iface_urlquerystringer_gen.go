@@ -42,17 +42,43 @@ func (g *GetArchivedThreads) URLQueryString() string {
 	return params.URLQueryString()
 }
 
+func (g *GetBans) URLQueryString() string {
+	params := make(urlQuery)
+
+	if !(g.Before == 0) {
+		params["before"] = g.Before
+	}
+
+	if !(g.After == 0) {
+		params["after"] = g.After
+	}
+
+	if !(g.Limit == 0) {
+		params["limit"] = g.Limit
+	}
+
+	return params.URLQueryString()
+}
+
 func (g *GetAuditLogs) URLQueryString() string {
 	params := make(urlQuery)
 
-	params["user_id"] = g.UserID
+	if !(g.UserID == 0) {
+		params["user_id"] = g.UserID
+	}
 
-	params["action_type"] = g.ActionType
+	if !(g.ActionType == 0) {
+		params["action_type"] = g.ActionType
+	}
 
 	if !(g.Before == 0) {
 		params["before"] = g.Before
 	}
 
+	if !(g.After == 0) {
+		params["after"] = g.After
+	}
+
 	if !(g.Limit == 0) {
 		params["limit"] = g.Limit
 	}
@@ -113,6 +113,9 @@ func (res *CreateInteractionResponse) prepare() (postBody interface{}, contentTy
 	if p.SpoilerTagContent && len(p.Content) > 0 {
 		p.Content = "|| " + p.Content + " ||"
 	}
+	if err = p.FindErrors(); err != nil {
+		return nil, "", err
+	}
 
 	if len(p.Files) == 0 {
 		postBody = res
@@ -160,3 +160,6 @@ const GuildScheduledEventUserAdd = "GUILD_SCHEDULED_EVENT_USER_ADD"
 
 // GuildScheduledEventUserRemove ...
 const GuildScheduledEventUserRemove = "GUILD_SCHEDULED_EVENT_USER_REMOVE"
+
+// GuildAuditLogEntryCreate Sent when a guild audit log entry is created. Requires the VIEW_AUDIT_LOG permission.
+const GuildAuditLogEntryCreate = "GUILD_AUDIT_LOG_ENTRY_CREATE"
@@ -15,6 +15,7 @@ func AllExcept(except ...string) []string {
 		ChannelDelete:                 0,
 		ChannelPinsUpdate:             0,
 		ChannelUpdate:                 0,
+		GuildAuditLogEntryCreate:      0,
 		GuildBanAdd:                   0,
 		GuildBanRemove:                0,
 		GuildCreate:                   0,
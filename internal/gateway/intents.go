@@ -54,7 +54,8 @@ const (
 	// - THREAD_MEMBERS_UPDATE
 	IntentGuildMembers
 
-	// IntentGuildBans
+	// IntentGuildBans is also known as the guild moderation intent.
+	// - GUILD_AUDIT_LOG_ENTRY_CREATE
 	// - GUILD_BAN_ADD
 	// - GUILD_BAN_REMOVE
 	IntentGuildBans
@@ -186,6 +187,8 @@ func EventToIntent(evt string, direct bool) Intent {
 			intent = IntentGuildBans
 		case event.GuildBanRemove:
 			intent = IntentGuildBans
+		case event.GuildAuditLogEntryCreate:
+			intent = IntentGuildBans
 		case event.GuildEmojisUpdate, event.GuildStickersUpdate:
 			intent = IntentGuildEmojisAndStickers
 		case event.GuildIntegrationsUpdate:
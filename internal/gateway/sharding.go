@@ -160,7 +160,8 @@ type ShardConfig struct {
 	// Setting it to 0 will default it to 1000.
 	IdentifiesPer24H uint
 
-	// URL is fetched from the gateway before initialising a connection
+	// URL is fetched from the gateway before initialising a connection, unless it is set. Such as to connect
+	// to a fake Discord server in tests.
 	URL string
 }
 
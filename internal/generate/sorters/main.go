@@ -113,6 +113,21 @@ type env struct {
 	Types   []Type
 }
 
+// ignoredTypes are exported structs that are never sorted, such as error types, iterators and builders.
+var ignoredTypes = map[string]bool{
+	"ErrMissingPermissions":        true,
+	"ErrInvalidPayload":            true,
+	"PayloadViolation":             true,
+	"BanIterator":                  true,
+	"GuildIterator":                true,
+	"ScheduledEventMemberIterator": true,
+	"ThreadIterator":               true,
+	"UserIterator":                 true,
+	"AuditLogIterator":             true,
+	"EmbedBuilder":                 true,
+	"ContentToken":                 true,
+}
+
 func getTypes(filename string) (types []Type) {
 	file, err := parser.ParseFile(token.NewFileSet(), filename, nil, 0)
 	if err != nil {
@@ -140,6 +155,9 @@ func getTypes(filename string) (types []Type) {
 		if name[0] != strings.ToUpper(name)[0] {
 			continue
 		}
+		if ignoredTypes[name] {
+			continue
+		}
 
 		t := Type{Name: name}
 		for _, field := range structDecl.Fields.List {
@@ -40,16 +40,29 @@ type ltBucket struct {
 	// this bucket is global if this.global is nil or this == this.global
 	global      *ltBucket
 	usingGlobal bool
+
+	// contenders are the buckets waiting for the global lock, by priority. Only used by the global bucket.
+	contendersMu sync.Mutex
+	contenders   map[*ltBucket]globalContender
+}
+
+type globalContender struct {
+	priority  Priority
+	createdAt time.Time
 }
 
 var _ RESTBucket = (*ltBucket)(nil)
 
 func (b *ltBucket) AcquireLock() (locked bool) {
+	return b.acquireLock(PriorityNormal, time.Now())
+}
+
+func (b *ltBucket) acquireLock(priority Priority, createdAt time.Time) (locked bool) {
 	if locked = b.atomicLock.AcquireLock(); !locked {
 		return false
 	}
 
-	if _, err := b.SelectiveGlobalLock(); err != nil {
+	if _, err := b.selectiveGlobalLock(priority, createdAt); err != nil {
 		b.atomicLock.Unlock()
 		return false
 	}
@@ -58,13 +71,23 @@ func (b *ltBucket) AcquireLock() (locked bool) {
 }
 
 func (b *ltBucket) SelectiveGlobalLock() (locked bool, err error) {
+	return b.selectiveGlobalLock(PriorityNormal, time.Now())
+}
+
+func (b *ltBucket) selectiveGlobalLock(priority Priority, createdAt time.Time) (locked bool, err error) {
 	if b != b.global {
 		// peek global ltBucket
 		b.global.mu.RLock()
 		globalLock := b.global.active()
 		b.global.mu.RUnlock()
 		// TODO: can this cause http 429?
-		if globalLock {
+		if !globalLock {
+			b.global.leave(b)
+		} else {
+			if !b.global.contend(b, priority, createdAt) {
+				return false, errors.New("a request of higher priority is waiting for the global lock")
+			}
+
 			// so check if the globalLock has changed since the read
 			if locked = b.global.atomicLock.AcquireLock(); !locked {
 				return false, errors.New("unable to acquire needed global lock")
@@ -78,12 +101,43 @@ func (b *ltBucket) SelectiveGlobalLock() (locked bool, err error) {
 				b.usingGlobal = true
 			}
 			b.global.mu.RUnlock()
+			b.global.leave(b)
 		}
 	}
 
 	return locked, nil
 }
 
+// contend registers the bucket as waiting for the global lock, and reports whether it is first in line.
+func (b *ltBucket) contend(bucket *ltBucket, priority Priority, createdAt time.Time) bool {
+	b.contendersMu.Lock()
+	defer b.contendersMu.Unlock()
+	if b.contenders == nil {
+		b.contenders = make(map[*ltBucket]globalContender)
+	}
+	b.contenders[bucket] = globalContender{priority: priority, createdAt: createdAt}
+
+	now := time.Now()
+	mine := util.EffectivePriority(int(priority), createdAt, now)
+	for other, contender := range b.contenders {
+		if other == bucket {
+			continue
+		}
+		theirs := util.EffectivePriority(int(contender.priority), contender.createdAt, now)
+		if theirs > mine || (theirs == mine && contender.createdAt.Before(createdAt)) {
+			return false
+		}
+	}
+	return true
+}
+
+// leave removes the bucket from the buckets waiting for the global lock.
+func (b *ltBucket) leave(bucket *ltBucket) {
+	b.contendersMu.Lock()
+	delete(b.contenders, bucket)
+	b.contendersMu.Unlock()
+}
+
 func (b *ltBucket) Transaction(ctx context.Context, do bucketTransaction) (resp *http.Response, body []byte, err error) {
 	// wait until you are next in line and you can acquire a lock
 	// this is to support timeout/cancellation for stacked requests
@@ -91,17 +145,26 @@ func (b *ltBucket) Transaction(ctx context.Context, do bucketTransaction) (resp
 	// reqA = /guilds/1/members?limit=100
 	// reqB = /guilds/1/members?limit=10
 	// reqB is a subset of A, and therefore reqA can create a response for reqB locally (must be deep copy - djp)
-	token := b.queue.NewTicket()
+	priority := PriorityFromContext(ctx)
+	createdAt := time.Now()
+	acquireLock := func() bool {
+		return b.acquireLock(priority, createdAt)
+	}
+
+	token := b.queue.NewPriorityTicket(int(priority))
 	for {
 		select {
 		case <-ctx.Done():
 			b.queue.Delete(token)
+			if b.global != nil && b != b.global {
+				b.global.leave(b)
+			}
 			return nil, nil, errors.New("time out")
 		case <-time.After(10 * time.Millisecond):
 			// TODO-perf: this wastes a lot of CPU usage
 		}
 
-		if !b.queue.Next(token, b.AcquireLock) {
+		if !b.queue.Next(token, acquireLock) {
 			continue
 		}
 		break
@@ -125,9 +188,11 @@ func (b *ltBucket) Transaction(ctx context.Context, do bucketTransaction) (resp
 	// check if rate limited and try to wait it out
 	var wait time.Duration
 	now := time.Now()
+	bucket.mu.RLock()
 	if bucket.resetTime.After(now) && bucket.remaining == 0 {
 		wait = bucket.resetTime.Sub(now)
 	}
+	bucket.mu.RUnlock()
 	if deadline, ok := ctx.Deadline(); ok && deadline.Before(time.Now().Add(wait)) {
 		return nil, nil, errors.New("time out, bucket resets in " + wait.String())
 	}
@@ -145,8 +210,12 @@ func (b *ltBucket) Transaction(ctx context.Context, do bucketTransaction) (resp
 
 	// update ltBucket info
 	// reduce remaining if needed
-	if !b.updateAfterRequest(resp.Header, resp.StatusCode) && bucket.remaining > 0 {
-		bucket.remaining--
+	if !b.updateAfterRequest(resp.Header, resp.StatusCode) {
+		bucket.mu.Lock()
+		if bucket.remaining > 0 {
+			bucket.remaining--
+		}
+		bucket.mu.Unlock()
 	}
 
 	return resp, body, nil
@@ -177,6 +246,8 @@ func (b *ltBucket) updateAfterRequest(header http.Header, statusCode int) (adjus
 	}
 	isGlobal = isGlobal || header.Get(XRateLimitGlobal) == "true"
 
+	// the state is read by Manager.Snapshot, Export and EstimateWait while requests are sent
+	b.mu.Lock()
 	// if this is not a 429 error we can determine if the local ltBucket is a global one or not
 	if statusCode != http.StatusTooManyRequests && b.hash == "" {
 		if isGlobal {
@@ -185,6 +256,10 @@ func (b *ltBucket) updateAfterRequest(header http.Header, statusCode int) (adjus
 			b.hash = bucketHash
 		}
 	}
+	if !isGlobal && !(b.global == nil || b == b.global) && bucketHash != "" {
+		b.hash = bucketHash
+	}
+	b.mu.Unlock()
 
 	var reset time.Time
 	var discordReset time.Time
@@ -204,21 +279,12 @@ func (b *ltBucket) updateAfterRequest(header http.Header, statusCode int) (adjus
 	}
 
 	// update ltBucket reference to whatever the header regards
-	var bucket *ltBucket
-	if isGlobal {
-		if b.global == b {
-			bucket = b
-		} else {
-			bucket = b.global
-		}
-		bucket.mu.Lock()
-		defer bucket.mu.Unlock()
-	} else {
-		bucket = b // no need to lock normal buckets
-		if !(b.global == nil || b == b.global) && bucketHash != "" {
-			b.hash = bucketHash
-		}
+	bucket := b
+	if isGlobal && b.global != nil {
+		bucket = b.global
 	}
+	bucket.mu.Lock()
+	defer bucket.mu.Unlock()
 
 	if discordReset.Before(time.Unix(0, int64(time.Hour))) {
 		return false
@@ -247,3 +313,8 @@ func (b *ltBucket) updateAfterRequest(header http.Header, statusCode int) (adjus
 func (b *ltBucket) active() bool {
 	return b.remaining >= 0 && !time.Now().After(b.resetTime)
 }
+
+// exhausted reports whether requests must wait for the bucket to reset.
+func (b *ltBucket) exhausted(now time.Time) bool {
+	return b.remaining == 0 && b.resetTime.After(now)
+}
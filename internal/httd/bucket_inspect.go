@@ -0,0 +1,117 @@
+package httd
+
+import (
+	"sort"
+	"time"
+)
+
+// BucketInfo describes the state of a rate limit bucket.
+type BucketInfo struct {
+	// Hash is the Discord bucket hash, or the hashed endpoint while Discord has not specified one.
+	Hash string
+
+	// Endpoints are the hashed endpoints sharing the bucket, see Request.HashEndpoint.
+	Endpoints []string
+
+	// Remaining is the number of requests that can be sent before Reset, or -1 when it is unknown.
+	Remaining int
+	Reset     time.Time
+
+	// Queued is the number of requests waiting for the bucket.
+	Queued int
+}
+
+// BucketsSnapshot describes the state of every rate limit bucket.
+type BucketsSnapshot struct {
+	Buckets []*BucketInfo
+
+	// GlobalLocked is true while the global rate limit is exhausted, which stops every request until GlobalReset.
+	GlobalLocked bool
+	GlobalReset  time.Time
+}
+
+// BucketInspector is implemented by bucket managers that can describe their buckets.
+type BucketInspector interface {
+	Snapshot() *BucketsSnapshot
+
+	// EstimateWait predicts how long a new request to the hashed endpoint must wait for the rate limits. It is
+	// a lower bound, as it only accounts for the current reset of the bucket and the global rate limit.
+	EstimateWait(hashedEndpoint string) time.Duration
+}
+
+var _ BucketInspector = (*Manager)(nil)
+
+func (r *Manager) Snapshot() *BucketsSnapshot {
+	snapshot := &BucketsSnapshot{}
+	infos := make(map[*ltBucket]*BucketInfo)
+
+	r.mu.RLock()
+	for id, pID := range r.proxy {
+		bucket, ok := r.buckets[pID]
+		if !ok {
+			continue
+		}
+		info, ok := infos[bucket]
+		if !ok {
+			info = bucket.info(pID)
+			infos[bucket] = info
+			snapshot.Buckets = append(snapshot.Buckets, info)
+		}
+		info.Endpoints = append(info.Endpoints, id)
+	}
+	r.mu.RUnlock()
+
+	for _, info := range snapshot.Buckets {
+		sort.Strings(info.Endpoints)
+	}
+	sort.Slice(snapshot.Buckets, func(i, j int) bool {
+		return snapshot.Buckets[i].Hash < snapshot.Buckets[j].Hash
+	})
+
+	r.global.mu.RLock()
+	snapshot.GlobalLocked = r.global.exhausted(time.Now())
+	snapshot.GlobalReset = r.global.resetTime
+	r.global.mu.RUnlock()
+	return snapshot
+}
+
+func (r *Manager) EstimateWait(hashedEndpoint string) (wait time.Duration) {
+	now := time.Now()
+	r.global.mu.RLock()
+	if r.global.exhausted(now) {
+		wait = r.global.resetTime.Sub(now)
+	}
+	r.global.mu.RUnlock()
+
+	r.mu.RLock()
+	pID, ok := r.proxy[hashedEndpoint]
+	if !ok {
+		pID = hashedEndpoint
+	}
+	bucket, ok := r.buckets[pID]
+	r.mu.RUnlock()
+	if !ok {
+		return wait
+	}
+
+	// the queued requests use up the remaining requests first
+	bucket.mu.RLock()
+	defer bucket.mu.RUnlock()
+	if bucket.remaining >= 0 && bucket.resetTime.After(now) && bucket.queue.Len() >= bucket.remaining {
+		if untilReset := bucket.resetTime.Sub(now); untilReset > wait {
+			wait = untilReset
+		}
+	}
+	return wait
+}
+
+func (b *ltBucket) info(hash string) *BucketInfo {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return &BucketInfo{
+		Hash:      hash,
+		Remaining: b.remaining,
+		Reset:     b.resetTime,
+		Queued:    b.queue.Len(),
+	}
+}
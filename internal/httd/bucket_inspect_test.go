@@ -0,0 +1,84 @@
+//go:build !integration
+// +build !integration
+
+package httd
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestManager_Snapshot(t *testing.T) {
+	messages := (&Request{Method: http.MethodPost, Endpoint: "/channels/1/messages"}).HashEndpoint()
+	user := (&Request{Method: http.MethodGet, Endpoint: "/users/@me"}).HashEndpoint()
+	reset := time.Now().Add(time.Hour)
+
+	manager := NewManager(nil)
+	manager.Bucket(messages, func(bucket RESTBucket) {
+		_, _, err := bucket.Transaction(context.Background(), func() (*http.Response, []byte, error) {
+			header := http.Header{}
+			header.Set(DisgordNormalizedHeader, "true")
+			header.Set(XRateLimitBucket, "abc")
+			header.Set(XRateLimitRemaining, "0")
+			header.Set(XRateLimitReset, strconv.FormatInt(reset.UnixNano()/int64(time.Millisecond), 10))
+			return &http.Response{StatusCode: http.StatusOK, Header: header}, nil, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	manager.Bucket(user, func(bucket RESTBucket) {})
+
+	snapshot := manager.Snapshot()
+	if len(snapshot.Buckets) != 2 || snapshot.GlobalLocked {
+		t.Fatalf("unexpected snapshot %+v", snapshot)
+	}
+	info := snapshot.Buckets[1]
+	if info.Hash != "abc" || !reflect.DeepEqual(info.Endpoints, []string{messages}) || info.Remaining != 0 || info.Queued != 0 {
+		t.Errorf("unexpected bucket info %+v", info)
+	}
+	if snapshot.Buckets[0].Hash != user || snapshot.Buckets[0].Remaining != -1 {
+		t.Errorf("expected an unknown bucket for %s, got %+v", user, snapshot.Buckets[0])
+	}
+
+	if wait := manager.EstimateWait(messages); wait <= 0 || wait > time.Hour {
+		t.Errorf("expected to wait for the bucket to reset, got %s", wait)
+	}
+	if wait := manager.EstimateWait(user); wait != 0 {
+		t.Errorf("expected no wait, got %s", wait)
+	}
+
+	manager.global.remaining = 0
+	manager.global.resetTime = time.Now().Add(time.Minute)
+	if !manager.Snapshot().GlobalLocked {
+		t.Error("expected the global rate limit to be locked")
+	}
+	if wait := manager.EstimateWait(user); wait <= 0 || wait > time.Minute {
+		t.Errorf("expected to wait for the global rate limit, got %s", wait)
+	}
+}
+
+func TestManager_SnapshotConcurrently(t *testing.T) {
+	manager := NewManager(nil)
+	stop := make(chan struct{})
+	wg := sendConcurrently(t, manager, stop)
+
+	hash := (&Request{Endpoint: "/channels/1/messages"}).HashEndpoint()
+	deadline := time.Now().Add(100 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		for _, info := range manager.Snapshot().Buckets {
+			if info.Remaining > 1000 {
+				t.Errorf("unexpected remaining requests %d", info.Remaining)
+			}
+		}
+		if wait := manager.EstimateWait(hash); wait > time.Hour {
+			t.Errorf("unexpected wait %s", wait)
+		}
+	}
+	close(stop)
+	wg.Wait()
+}
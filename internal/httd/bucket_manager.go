@@ -1,7 +1,10 @@
 package httd
 
 import (
+	"context"
+	"net/http"
 	"sync"
+	"time"
 )
 
 const GlobalHash = "global"
@@ -24,9 +27,10 @@ func NewManager(defaultRelations map[string]string) *Manager {
 	global.hash = GlobalHash
 
 	m := &Manager{
-		proxy:   make(map[string]string),
-		buckets: make(map[string]*ltBucket),
-		global:  global,
+		proxy:      make(map[string]string),
+		discovered: make(map[string]time.Time),
+		buckets:    make(map[string]*ltBucket),
+		global:     global,
 	}
 
 	hashRelations := relationsByBucketID(defaultRelations)
@@ -54,6 +58,9 @@ type Manager struct {
 	proxy   map[string]string
 	buckets map[string]*ltBucket
 
+	// discovered holds when a local endpoint hash was linked to a discord ltBucket hash
+	discovered map[string]time.Time
+
 	global *ltBucket
 }
 
@@ -97,6 +104,7 @@ func (r *Manager) UpdateProxyID(id, pID, bucketHash string) {
 		r.buckets[bucketHash] = r.buckets[r.proxy[id]]
 	}
 	r.proxy[id] = bucketHash
+	r.discovered[id] = time.Now()
 	r.mu.Unlock()
 }
 
@@ -126,3 +134,25 @@ func (r *Manager) Bucket(id string, cb func(bucket RESTBucket)) {
 func (r *Manager) Consolidate() {
 
 }
+
+// NewPassthroughManager creates a bucket manager that sends every request immediately, for when the rate
+// limits are handled elsewhere, such as by a REST proxy shared between processes.
+func NewPassthroughManager() RESTBucketManager {
+	return passthroughManager{}
+}
+
+type passthroughManager struct{}
+
+var _ RESTBucketManager = passthroughManager{}
+
+func (passthroughManager) Bucket(_ string, cb func(bucket RESTBucket)) {
+	cb(passthroughManager{})
+}
+
+func (passthroughManager) BucketGrouping() (group map[string][]string) {
+	return map[string][]string{}
+}
+
+func (passthroughManager) Transaction(_ context.Context, do func() (*http.Response, []byte, error)) (*http.Response, []byte, error) {
+	return do()
+}
@@ -0,0 +1,124 @@
+package httd
+
+import (
+	"errors"
+	"time"
+
+	"github.com/andersfylling/disgord/json"
+)
+
+// BucketPersister is implemented by bucket managers that can save what they learned about the rate limits,
+// such that a restarted bot does not need to discover every bucket again.
+type BucketPersister interface {
+	// Export returns the buckets as JSON.
+	Export() ([]byte, error)
+
+	// Import loads buckets created by Export. Relations discovered more than maxAge ago are skipped,
+	// unless maxAge is 0, and buckets that have been reset are always skipped.
+	Import(data []byte, maxAge time.Duration) error
+}
+
+// managerSnapshot is the JSON format of Manager.Export
+type managerSnapshot struct {
+	Relations map[string]bucketRelation `json:"relations"`
+	Buckets   map[string]BucketState    `json:"buckets"`
+}
+
+type bucketRelation struct {
+	Bucket       string    `json:"bucket"`
+	DiscoveredAt time.Time `json:"discovered_at"`
+}
+
+var _ BucketPersister = (*Manager)(nil)
+
+// Export returns which local endpoint hashes belong to which discord bucket hash, and the state of the
+// buckets that have not yet been reset.
+func (r *Manager) Export() ([]byte, error) {
+	snapshot := managerSnapshot{
+		Relations: make(map[string]bucketRelation),
+		Buckets:   make(map[string]BucketState),
+	}
+	now := time.Now()
+	export := func(hash string, bucket *ltBucket) {
+		bucket.mu.RLock()
+		if bucket.remaining >= 0 && bucket.resetTime.After(now) {
+			snapshot.Buckets[hash] = BucketState{Remaining: bucket.remaining, Reset: bucket.resetTime}
+		}
+		bucket.mu.RUnlock()
+	}
+
+	r.mu.RLock()
+	for id, hash := range r.proxy {
+		if id != hash {
+			snapshot.Relations[id] = bucketRelation{Bucket: hash, DiscoveredAt: r.discovered[id]}
+		}
+	}
+	for hash, bucket := range r.buckets {
+		bucket.mu.RLock()
+		discovered := hash == bucket.hash
+		bucket.mu.RUnlock()
+		if bucket != r.global && discovered {
+			export(hash, bucket)
+		}
+	}
+	r.mu.RUnlock()
+	export(GlobalHash, r.global)
+
+	return json.Marshal(snapshot)
+}
+
+// Import loads the buckets created by Export, like the default relations given to NewManager. Local
+// endpoint hashes that have already been linked to a discord bucket hash are not affected.
+func (r *Manager) Import(data []byte, maxAge time.Duration) error {
+	var snapshot managerSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return err
+	}
+	now := time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, relation := range snapshot.Relations {
+		if relation.Bucket == "" {
+			return errors.New("missing bucket hash for " + id)
+		}
+		if maxAge > 0 && now.Sub(relation.DiscoveredAt) > maxAge {
+			continue
+		}
+		if pID, ok := r.proxy[id]; ok && pID != id {
+			continue
+		}
+
+		bucket, ok := r.buckets[relation.Bucket]
+		if !ok {
+			if bucket, ok = r.buckets[id]; !ok {
+				bucket = newLeakyBucket(r.global)
+			}
+			bucket.mu.Lock()
+			bucket.hash = relation.Bucket
+			bucket.mu.Unlock()
+			r.buckets[relation.Bucket] = bucket
+		}
+		r.proxy[id] = relation.Bucket
+		r.discovered[id] = relation.DiscoveredAt
+	}
+
+	for hash, state := range snapshot.Buckets {
+		bucket, ok := r.buckets[hash]
+		if hash == GlobalHash {
+			bucket, ok = r.global, true
+		}
+		if !ok || !state.Reset.After(now) {
+			continue
+		}
+
+		// the discord reset time is left as is, such that the next response replaces the imported state
+		bucket.mu.Lock()
+		if state.Reset.After(bucket.resetTime) {
+			bucket.remaining = state.Remaining
+			bucket.resetTime = state.Reset
+		}
+		bucket.mu.Unlock()
+	}
+	return nil
+}
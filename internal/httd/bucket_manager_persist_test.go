@@ -0,0 +1,143 @@
+//go:build !integration
+// +build !integration
+
+package httd
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestManager_ExportImport(t *testing.T) {
+	localHash := (&Request{Endpoint: "/channels/1/messages"}).HashEndpoint()
+	reset := time.Now().Add(time.Hour).UnixNano() / int64(time.Millisecond)
+
+	exporter := NewManager(nil)
+	exporter.Bucket(localHash, func(bucket RESTBucket) {
+		_, _, err := bucket.Transaction(context.Background(), func() (*http.Response, []byte, error) {
+			header := http.Header{}
+			header.Set(DisgordNormalizedHeader, "true")
+			header.Set(XRateLimitBucket, "abc")
+			header.Set(XRateLimitRemaining, "0")
+			header.Set(XRateLimitReset, strconv.FormatInt(reset, 10))
+			return &http.Response{StatusCode: http.StatusOK, Header: header}, nil, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	exporter.Bucket("/users/@me", func(bucket RESTBucket) {})
+
+	data, err := exporter.Export()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("import", func(t *testing.T) {
+		manager := NewManager(nil)
+		if err := manager.Import(data, time.Hour); err != nil {
+			t.Fatal(err)
+		}
+
+		expects := map[string][]string{"abc": {localHash}}
+		if group := manager.BucketGrouping(); !reflect.DeepEqual(group, expects) {
+			t.Errorf("expected bucket grouping %v, got %v", expects, group)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		manager.Bucket(localHash, func(bucket RESTBucket) {
+			_, _, err := bucket.Transaction(ctx, func() (*http.Response, []byte, error) {
+				t.Error("expected the imported bucket to be exhausted")
+				return nil, nil, nil
+			})
+			if err == nil || !strings.Contains(err.Error(), "bucket resets in") {
+				t.Errorf("expected a time out error, got %v", err)
+			}
+		})
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		time.Sleep(time.Millisecond)
+
+		manager := NewManager(nil)
+		if err := manager.Import(data, time.Nanosecond); err != nil {
+			t.Fatal(err)
+		}
+		if group := manager.BucketGrouping(); len(group) > 0 {
+			t.Errorf("expected stale relations to be skipped, got %v", group)
+		}
+	})
+
+	t.Run("discovered", func(t *testing.T) {
+		manager := NewManager(nil)
+		manager.ProxyID(localHash)
+		manager.UpdateProxyID(localHash, localHash, "def")
+		if err := manager.Import(data, 0); err != nil {
+			t.Fatal(err)
+		}
+		if pID := manager.ProxyID(localHash); pID != "def" {
+			t.Errorf("expected discovered buckets to be kept, got %s", pID)
+		}
+	})
+}
+
+// sendConcurrently sends requests to several endpoints at once, until stop is closed.
+func sendConcurrently(t *testing.T, manager *Manager, stop <-chan struct{}) *sync.WaitGroup {
+	reset := strconv.FormatInt(time.Now().Add(time.Hour).UnixNano()/int64(time.Millisecond), 10)
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		hash := (&Request{Endpoint: "/channels/" + strconv.Itoa(i+1) + "/messages"}).HashEndpoint()
+		bucketHash := "bucket" + strconv.Itoa(i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				manager.Bucket(hash, func(bucket RESTBucket) {
+					_, _, err := bucket.Transaction(context.Background(), func() (*http.Response, []byte, error) {
+						header := http.Header{}
+						header.Set(DisgordNormalizedHeader, "true")
+						header.Set(XRateLimitBucket, bucketHash)
+						header.Set(XRateLimitRemaining, "1000")
+						header.Set(XRateLimitReset, reset)
+						return &http.Response{StatusCode: http.StatusOK, Header: header}, nil, nil
+					})
+					if err != nil {
+						t.Error(err)
+					}
+				})
+			}
+		}()
+	}
+	return wg
+}
+
+func TestManager_ExportImportConcurrently(t *testing.T) {
+	manager := NewManager(nil)
+	stop := make(chan struct{})
+	wg := sendConcurrently(t, manager, stop)
+
+	deadline := time.Now().Add(100 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		data, err := manager.Export()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = manager.Import(data, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	close(stop)
+	wg.Wait()
+}
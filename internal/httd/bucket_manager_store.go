@@ -0,0 +1,165 @@
+package httd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// how long a local hash without a Discord bucket hash in the store is remembered, before the store is asked again
+const storeManagerMissTTL = time.Second
+
+// NewStoreManager creates a RESTBucketManager that keeps the rate limits, and which endpoints share a
+// bucket, in a BucketStore. Managers using the same store coordinate their requests, such that several
+// processes can share one bot token without exceeding the rate limits together.
+func NewStoreManager(store BucketStore) *StoreManager {
+	return &StoreManager{
+		store:     store,
+		relations: make(map[string]string),
+		misses:    make(map[string]time.Time),
+	}
+}
+
+type StoreManager struct {
+	store BucketStore
+
+	// relations caches the Discord bucket hashes of local hashes found in the store, and misses holds when
+	// local hashes that were not found can be looked up again
+	mu        sync.RWMutex
+	relations map[string]string
+	misses    map[string]time.Time
+}
+
+var _ RESTBucketManager = (*StoreManager)(nil)
+
+func (m *StoreManager) Bucket(localHash string, cb func(bucket RESTBucket)) {
+	cb(&storeBucket{
+		manager:   m,
+		localHash: localHash,
+		hash:      m.bucketHash(localHash),
+	})
+}
+
+func (m *StoreManager) BucketGrouping() (group map[string][]string) {
+	group = make(map[string][]string)
+	relations, err := m.store.Relations(context.Background())
+	if err != nil {
+		return group
+	}
+	for localHash, bucketHash := range relations {
+		group[bucketHash] = append(group[bucketHash], localHash)
+	}
+	return group
+}
+
+// bucketHash returns the Discord bucket hash of the local hash, or the local hash when it is still unknown.
+// A bucket discovered by any manager using the store is picked up here, within storeManagerMissTTL.
+func (m *StoreManager) bucketHash(localHash string) string {
+	now := time.Now()
+	m.mu.RLock()
+	hash, ok := m.relations[localHash]
+	missed := m.misses[localHash].After(now)
+	m.mu.RUnlock()
+	if ok {
+		return hash
+	}
+	if missed {
+		return localHash
+	}
+
+	relations, err := m.store.Relations(context.Background())
+	if err != nil {
+		return localHash
+	}
+	m.mu.Lock()
+	for id, bucketHash := range relations {
+		m.relations[id] = bucketHash
+	}
+	m.mu.Unlock()
+
+	if hash, ok = relations[localHash]; ok {
+		return hash
+	}
+	m.mu.Lock()
+	m.misses[localHash] = now.Add(storeManagerMissTTL)
+	m.mu.Unlock()
+	return localHash
+}
+
+// update stores the rate limit info of a response. Note that the headers must be normalized.
+func (m *StoreManager) update(ctx context.Context, localHash, hash string, resp *http.Response) error {
+	header := resp.Header
+
+	var reset time.Time
+	if resetStr := header.Get(XRateLimitReset); resetStr != "" {
+		epoch, _ := strconv.ParseInt(resetStr, 10, 64)
+		reset = time.Unix(0, epoch*int64(time.Millisecond))
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests && header.Get(XRateLimitGlobal) == "true" {
+		return m.store.LockGlobal(ctx, reset)
+	}
+
+	if bucketHash := header.Get(XRateLimitBucket); bucketHash != "" && bucketHash != hash {
+		if err := m.store.SetBucketHash(ctx, localHash, bucketHash); err != nil {
+			return err
+		}
+		m.mu.Lock()
+		m.relations[localHash] = bucketHash
+		delete(m.misses, localHash)
+		m.mu.Unlock()
+		hash = bucketHash
+	}
+
+	if reset.IsZero() {
+		return nil
+	}
+	state := BucketState{Reset: reset}
+	if remaining, err := strconv.Atoi(header.Get(XRateLimitRemaining)); err == nil {
+		state.Remaining = remaining
+	} else if resp.StatusCode != http.StatusTooManyRequests {
+		return nil
+	}
+	return m.store.Update(ctx, hash, state)
+}
+
+type storeBucket struct {
+	manager   *StoreManager
+	localHash string
+	hash      string
+}
+
+var _ RESTBucket = (*storeBucket)(nil)
+
+func (b *storeBucket) Transaction(ctx context.Context, do func() (*http.Response, []byte, error)) (*http.Response, []byte, error) {
+	for {
+		wait, err := b.manager.store.TakeToken(ctx, b.hash)
+		if err != nil {
+			return nil, nil, err
+		}
+		if wait <= 0 {
+			break
+		}
+
+		if deadline, ok := ctx.Deadline(); ok && deadline.Before(time.Now().Add(wait)) {
+			return nil, nil, errors.New("time out, bucket resets in " + wait.String())
+		}
+		select {
+		case <-ctx.Done():
+			return nil, nil, errors.New("time out")
+		case <-time.After(wait):
+		}
+	}
+
+	resp, body, err := do()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the response is valid even if the store could not be updated
+	_ = b.manager.update(ctx, b.localHash, b.hash, resp)
+	return resp, body, nil
+}
@@ -0,0 +1,151 @@
+package httd
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BucketStore holds the rate limit state of a StoreManager. Every method must be atomic, such that one store
+// can be shared by several managers, eg. in different processes using the same bot token.
+type BucketStore interface {
+	// TakeToken reserves a request in the bucket. When the bucket, or every bucket due to a global rate
+	// limit, is exhausted, nothing is reserved and the returned duration is how long to wait before trying
+	// again. Buckets without any known state allow every request.
+	TakeToken(ctx context.Context, bucketHash string) (wait time.Duration, err error)
+
+	// Update stores the rate limit state from the response headers of a request in the bucket. The state with
+	// the latest reset is kept, and the lowest remaining count when the reset is the same.
+	Update(ctx context.Context, bucketHash string, state BucketState) error
+
+	// LockGlobal denies every request until the given time, after a global rate limit.
+	LockGlobal(ctx context.Context, until time.Time) error
+
+	// SetBucketHash links a local hash, see Request.HashEndpoint, to the Discord bucket hash it belongs to.
+	SetBucketHash(ctx context.Context, localHash, bucketHash string) error
+
+	// Relations returns the Discord bucket hash of every local hash that has been linked to one.
+	Relations(ctx context.Context) (map[string]string, error)
+}
+
+// BucketState is the rate limit state of a bucket, as given by the response headers.
+type BucketState struct {
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+}
+
+// bucketStoreState implements the logic of a BucketStore, without any synchronization.
+type bucketStoreState struct {
+	GlobalReset time.Time               `json:"global_reset"`
+	Buckets     map[string]*BucketState `json:"buckets"`
+	Relations   map[string]string       `json:"relations"`
+}
+
+func newBucketStoreState() *bucketStoreState {
+	return &bucketStoreState{
+		Buckets:   make(map[string]*BucketState),
+		Relations: make(map[string]string),
+	}
+}
+
+// takeToken reserves a request in the bucket, and reports whether the state changed by doing so.
+func (s *bucketStoreState) takeToken(now time.Time, bucketHash string) (wait time.Duration, changed bool) {
+	if s.GlobalReset.After(now) {
+		return s.GlobalReset.Sub(now), false
+	}
+
+	bucket, ok := s.Buckets[bucketHash]
+	if !ok || !bucket.Reset.After(now) {
+		return 0, false
+	}
+	if bucket.Remaining > 0 {
+		bucket.Remaining--
+		return 0, true
+	}
+	return bucket.Reset.Sub(now), false
+}
+
+func (s *bucketStoreState) update(bucketHash string, state BucketState) {
+	bucket, ok := s.Buckets[bucketHash]
+	if !ok || state.Reset.After(bucket.Reset) {
+		s.Buckets[bucketHash] = &state
+	} else if state.Reset.Equal(bucket.Reset) && state.Remaining < bucket.Remaining {
+		bucket.Remaining = state.Remaining
+	}
+}
+
+func (s *bucketStoreState) lockGlobal(until time.Time) {
+	if until.After(s.GlobalReset) {
+		s.GlobalReset = until
+	}
+}
+
+func (s *bucketStoreState) relations() map[string]string {
+	relations := make(map[string]string, len(s.Relations))
+	for localHash, bucketHash := range s.Relations {
+		relations[localHash] = bucketHash
+	}
+	return relations
+}
+
+// prune removes buckets that have been reset, as requests to them are no longer limited. It reports whether
+// any bucket was removed.
+func (s *bucketStoreState) prune(now time.Time) (pruned bool) {
+	for hash, bucket := range s.Buckets {
+		if !bucket.Reset.After(now) {
+			delete(s.Buckets, hash)
+			pruned = true
+		}
+	}
+	return pruned
+}
+
+// NewMemoryBucketStore creates a BucketStore that keeps the state in memory. It can be shared by
+// several clients in the same process.
+func NewMemoryBucketStore() *MemoryBucketStore {
+	return &MemoryBucketStore{state: newBucketStoreState()}
+}
+
+type MemoryBucketStore struct {
+	mu    sync.Mutex
+	state *bucketStoreState
+}
+
+var _ BucketStore = (*MemoryBucketStore)(nil)
+
+func (m *MemoryBucketStore) TakeToken(_ context.Context, bucketHash string) (time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.state.prune(now)
+	wait, _ := m.state.takeToken(now, bucketHash)
+	return wait, nil
+}
+
+func (m *MemoryBucketStore) Update(_ context.Context, bucketHash string, state BucketState) error {
+	m.mu.Lock()
+	m.state.update(bucketHash, state)
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *MemoryBucketStore) LockGlobal(_ context.Context, until time.Time) error {
+	m.mu.Lock()
+	m.state.lockGlobal(until)
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *MemoryBucketStore) SetBucketHash(_ context.Context, localHash, bucketHash string) error {
+	m.mu.Lock()
+	m.state.Relations[localHash] = bucketHash
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *MemoryBucketStore) Relations(_ context.Context) (map[string]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state.relations(), nil
+}
@@ -0,0 +1,161 @@
+package httd
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/andersfylling/disgord/json"
+)
+
+// the longest wait between attempts to lock the file
+const fileBucketStoreMaxBackoff = 50 * time.Millisecond
+
+// NewFileBucketStore creates a BucketStore that keeps the state in a JSON file, which allows processes on the
+// same host to share rate limits. Every operation holds an exclusive OS file lock (flock, or LockFileEx on
+// Windows) on a lock file next to it, with the suffix ".lock". The lock file is kept, and the lock is released
+// by the OS when a process stops while holding it.
+func NewFileBucketStore(path string) *FileBucketStore {
+	return &FileBucketStore{path: path}
+}
+
+type FileBucketStore struct {
+	mu   sync.Mutex // avoids lock file contention within the process
+	path string
+}
+
+var _ BucketStore = (*FileBucketStore)(nil)
+
+func (f *FileBucketStore) TakeToken(ctx context.Context, bucketHash string) (wait time.Duration, err error) {
+	err = f.transaction(ctx, func(state *bucketStoreState) (changed bool) {
+		now := time.Now()
+		pruned := state.prune(now)
+		wait, changed = state.takeToken(now, bucketHash)
+		return pruned || changed
+	})
+	return wait, err
+}
+
+func (f *FileBucketStore) Update(ctx context.Context, bucketHash string, state BucketState) error {
+	return f.transaction(ctx, func(s *bucketStoreState) bool {
+		s.update(bucketHash, state)
+		return true
+	})
+}
+
+func (f *FileBucketStore) LockGlobal(ctx context.Context, until time.Time) error {
+	return f.transaction(ctx, func(state *bucketStoreState) bool {
+		state.lockGlobal(until)
+		return true
+	})
+}
+
+func (f *FileBucketStore) SetBucketHash(ctx context.Context, localHash, bucketHash string) error {
+	return f.transaction(ctx, func(state *bucketStoreState) bool {
+		state.Relations[localHash] = bucketHash
+		return true
+	})
+}
+
+func (f *FileBucketStore) Relations(ctx context.Context) (relations map[string]string, err error) {
+	err = f.transaction(ctx, func(state *bucketStoreState) bool {
+		relations = state.relations()
+		return false
+	})
+	return relations, err
+}
+
+// transaction locks the file, and passes the stored state to do. The state is only written back when do
+// reports that it changed, as every write replaces the whole file.
+func (f *FileBucketStore) transaction(ctx context.Context, do func(state *bucketStoreState) (changed bool)) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	unlock, err := f.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	state, err := f.load()
+	if err != nil {
+		return err
+	}
+	if !do(state) {
+		return nil
+	}
+	return f.save(state)
+}
+
+func (f *FileBucketStore) lock(ctx context.Context) (unlock func(), err error) {
+	// the data file is replaced on every write, so the lock must be held on a file that is never replaced
+	lockPath := f.path + ".lock"
+	file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	backoff := time.Millisecond
+	for {
+		locked, err := tryLockFile(file)
+		if err != nil {
+			_ = file.Close()
+			return nil, err
+		}
+		if locked {
+			return func() {
+				_ = unlockFile(file)
+				_ = file.Close()
+			}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			_ = file.Close()
+			return nil, errors.New("time out, unable to lock " + lockPath)
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > fileBucketStoreMaxBackoff {
+			backoff = fileBucketStoreMaxBackoff
+		}
+	}
+}
+
+func (f *FileBucketStore) load() (*bucketStoreState, error) {
+	state := newBucketStoreState()
+	data, err := ioutil.ReadFile(f.path)
+	if os.IsNotExist(err) || (err == nil && len(data) == 0) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	if state.Buckets == nil {
+		state.Buckets = make(map[string]*BucketState)
+	}
+	if state.Relations == nil {
+		state.Relations = make(map[string]string)
+	}
+	return state, nil
+}
+
+// save replaces the file, such that a process that stops while writing never leaves a partial file behind.
+func (f *FileBucketStore) save(state *bucketStoreState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	tmp := f.path + ".tmp"
+	if err = ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.path)
+}
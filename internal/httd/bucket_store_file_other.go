@@ -0,0 +1,17 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !windows
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!windows
+
+package httd
+
+import (
+	"errors"
+	"os"
+)
+
+func tryLockFile(file *os.File) (locked bool, err error) {
+	return false, errors.New("FileBucketStore is not supported on this platform, as it lacks file locks")
+}
+
+func unlockFile(file *os.File) error {
+	return nil
+}
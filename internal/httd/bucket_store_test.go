@@ -0,0 +1,199 @@
+//go:build !integration
+// +build !integration
+
+package httd
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func testBucketStore(t *testing.T, store, other BucketStore) {
+	ctx := context.Background()
+	reset := time.Now().Add(time.Hour).Truncate(time.Millisecond)
+
+	if wait, err := store.TakeToken(ctx, "a"); err != nil || wait != 0 {
+		t.Fatalf("expected unknown buckets to allow requests, got %s, %v", wait, err)
+	}
+
+	if err := store.Update(ctx, "a", BucketState{Remaining: 1, Reset: reset}); err != nil {
+		t.Fatal(err)
+	}
+	if wait, _ := other.TakeToken(ctx, "a"); wait != 0 {
+		t.Fatalf("expected a remaining request, got wait %s", wait)
+	}
+	if wait, _ := store.TakeToken(ctx, "a"); wait <= 0 || wait > time.Hour {
+		t.Fatalf("expected to wait for the bucket to reset, got %s", wait)
+	}
+
+	// an older response must not reset the remaining count
+	_ = other.Update(ctx, "a", BucketState{Remaining: 5, Reset: reset})
+	if wait, _ := store.TakeToken(ctx, "a"); wait <= 0 {
+		t.Error("expected the lowest remaining count to be kept")
+	}
+
+	if err := other.LockGlobal(ctx, time.Now().Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if wait, _ := store.TakeToken(ctx, "b"); wait <= 0 || wait > time.Minute {
+		t.Errorf("expected every bucket to wait for the global rate limit, got %s", wait)
+	}
+
+	if err := store.SetBucketHash(ctx, "/channels/1/messages", "abc"); err != nil {
+		t.Fatal(err)
+	}
+	relations, err := other.Relations(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(relations, map[string]string{"/channels/1/messages": "abc"}) {
+		t.Errorf("unexpected relations %v", relations)
+	}
+}
+
+func TestMemoryBucketStore(t *testing.T) {
+	store := NewMemoryBucketStore()
+	testBucketStore(t, store, store)
+}
+
+func TestFileBucketStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "disgord")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "buckets.json")
+	testBucketStore(t, NewFileBucketStore(path), NewFileBucketStore(path))
+
+	t.Run("locked", func(t *testing.T) {
+		file, err := os.OpenFile(path+".lock", os.O_RDWR, 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer file.Close()
+		if locked, err := tryLockFile(file); !locked {
+			t.Fatalf("expected to lock the file, got %v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		if _, err := NewFileBucketStore(path).Relations(ctx); err == nil {
+			t.Error("expected to wait for the lock held by another process")
+		}
+
+		if err := unlockFile(file); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := NewFileBucketStore(path).Relations(context.Background()); err != nil {
+			t.Errorf("expected the lock to be released, got %v", err)
+		}
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		unchanged := filepath.Join(dir, "unchanged.json")
+		if _, err := NewFileBucketStore(unchanged).TakeToken(context.Background(), "unknown"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := os.Stat(unchanged); !os.IsNotExist(err) {
+			t.Errorf("expected no write for a bucket without limits, got %v", err)
+		}
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		// each store locks the file independently, like separate processes
+		stores := []BucketStore{NewFileBucketStore(path), NewFileBucketStore(path), NewFileBucketStore(path)}
+		var wg sync.WaitGroup
+		for i, store := range stores {
+			wg.Add(1)
+			go func(i int, store BucketStore) {
+				defer wg.Done()
+				for j := 0; j < 20; j++ {
+					localHash := strconv.Itoa(i) + "-" + strconv.Itoa(j)
+					if err := store.SetBucketHash(context.Background(), localHash, "abc"); err != nil {
+						t.Error(err)
+					}
+				}
+			}(i, store)
+		}
+		wg.Wait()
+
+		relations, err := stores[0].Relations(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(relations) < len(stores)*20 {
+			t.Errorf("expected no lost updates, got %d relations", len(relations))
+		}
+	})
+}
+
+type relationsCounter struct {
+	*MemoryBucketStore
+	calls int
+}
+
+func (c *relationsCounter) Relations(ctx context.Context) (map[string]string, error) {
+	c.calls++
+	return c.MemoryBucketStore.Relations(ctx)
+}
+
+func TestStoreManager_Miss(t *testing.T) {
+	store := &relationsCounter{MemoryBucketStore: NewMemoryBucketStore()}
+	manager := NewStoreManager(store)
+
+	for i := 0; i < 3; i++ {
+		if hash := manager.bucketHash("unknown"); hash != "unknown" {
+			t.Errorf("expected the local hash, got %s", hash)
+		}
+	}
+	if store.calls != 1 {
+		t.Errorf("expected the miss to be remembered, got %d look ups", store.calls)
+	}
+}
+
+func TestStoreManager(t *testing.T) {
+	store := NewMemoryBucketStore()
+	a, b := NewStoreManager(store), NewStoreManager(store)
+
+	localHash := (&Request{Endpoint: "/channels/1/messages"}).HashEndpoint()
+	reset := time.Now().Add(time.Hour).UnixNano() / int64(time.Millisecond)
+
+	a.Bucket(localHash, func(bucket RESTBucket) {
+		_, _, err := bucket.Transaction(context.Background(), func() (*http.Response, []byte, error) {
+			header := http.Header{}
+			header.Set(XRateLimitBucket, "abc")
+			header.Set(XRateLimitRemaining, "0")
+			header.Set(XRateLimitReset, strconv.FormatInt(reset, 10))
+			return &http.Response{StatusCode: http.StatusOK, Header: header}, nil, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	if group := b.BucketGrouping(); !reflect.DeepEqual(group["abc"], []string{localHash}) {
+		t.Errorf("expected the bucket to be shared, got %v", group)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	b.Bucket(localHash, func(bucket RESTBucket) {
+		_, _, err := bucket.Transaction(ctx, func() (*http.Response, []byte, error) {
+			t.Error("expected the request to wait for the exhausted bucket")
+			return nil, nil, nil
+		})
+		if err == nil || !strings.Contains(err.Error(), "bucket resets in") {
+			t.Errorf("expected a time out error, got %v", err)
+		}
+	})
+}
@@ -158,3 +158,33 @@ func TestLtBucket_RespectRateLimit(t *testing.T) {
 	})
 
 }
+
+func TestLtBucket_globalPriority(t *testing.T) {
+	global := newLeakyBucket(nil)
+	global.global = global
+	global.remaining = 10
+	global.resetTime = time.Now().Add(time.Hour)
+
+	low := newLeakyBucket(global)
+	high := newLeakyBucket(global)
+
+	if !global.contend(high, PriorityHigh, time.Now()) {
+		t.Fatal("expected the only contender to be first in line")
+	}
+	if _, err := low.selectiveGlobalLock(PriorityLow, time.Now()); err == nil {
+		t.Fatal("expected the low priority bucket to wait for the high priority bucket")
+	}
+
+	if _, err := high.selectiveGlobalLock(PriorityHigh, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	high.usingGlobal = false
+	global.atomicLock.Unlock()
+
+	if _, err := low.selectiveGlobalLock(PriorityLow, time.Now()); err != nil {
+		t.Errorf("expected the global lock once no request of higher priority is waiting, got %v", err)
+	}
+	if len(global.contenders) != 0 {
+		t.Errorf("expected no remaining contenders, got %d", len(global.contenders))
+	}
+}
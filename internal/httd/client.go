@@ -10,6 +10,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/andersfylling/disgord/json"
@@ -73,6 +74,9 @@ type ErrREST struct {
 	HTTPCode       int      `json:"-"`
 	Bucket         []string `json:"-"`
 	HashedEndpoint string   `json:"-"`
+
+	// Errors holds the flattened field errors of a rejected request body, such as an invalid form body.
+	Errors []FieldError `json:"-"`
 }
 
 var _ error = (*ErrREST)(nil)
@@ -81,6 +85,37 @@ func (e *ErrREST) Error() string {
 	return fmt.Sprintf("%s\n%s\n%s => %+v", e.Msg, e.Suggestion, e.HashedEndpoint, e.Bucket)
 }
 
+// APICode returns the JSON error code of Discord as an APIErrorCode.
+func (e *ErrREST) APICode() APIErrorCode {
+	return APIErrorCode(e.Code)
+}
+
+// Is allows a APIErrorCode to be used as a sentinel error, eg. errors.Is(err, disgord.UnknownMessage).
+func (e *ErrREST) Is(target error) bool {
+	code, ok := target.(APIErrorCode)
+	return ok && e.APICode() == code && e.Code != 0
+}
+
+// decodeDiscordError stores the error code, message and field errors of a Discord error response.
+func (e *ErrREST) decodeDiscordError(body []byte) {
+	var discordErr struct {
+		Code    int             `json:"code"`
+		Message string          `json:"message"`
+		Errors  json.RawMessage `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &discordErr); err != nil {
+		return
+	}
+
+	e.Code = discordErr.Code
+	if discordErr.Message != "" {
+		e.Msg = discordErr.Message
+	}
+	if len(discordErr.Errors) > 0 {
+		e.Errors = flattenFieldErrors("", discordErr.Errors, nil)
+	}
+}
+
 type HttpClientDoer interface {
 	Do(req *http.Request) (*http.Response, error)
 }
@@ -92,12 +127,58 @@ type Client struct {
 	httpClient                   HttpClientDoer
 	cancelRequestWhenRateLimited bool
 	buckets                      RESTBucketManager
+	retry                        *RetryPolicy
+	roundTrip                    RoundTrip
+	invalidRequests              *invalidRequests
 }
 
 func (c *Client) BucketGrouping() (group map[string][]string) {
 	return c.buckets.BucketGrouping()
 }
 
+// InvalidRequests returns the number of invalid requests within InvalidRequestWindow.
+func (c *Client) InvalidRequests() int {
+	return c.invalidRequests.Count()
+}
+
+// ExportBuckets exports the rate limit buckets, see BucketPersister.
+func (c *Client) ExportBuckets() ([]byte, error) {
+	persister, ok := c.buckets.(BucketPersister)
+	if !ok {
+		return nil, errors.New("the bucket manager does not support exporting buckets")
+	}
+	return persister.Export()
+}
+
+// ImportBuckets loads rate limit buckets created by ExportBuckets, see BucketPersister.
+func (c *Client) ImportBuckets(data []byte, maxAge time.Duration) error {
+	persister, ok := c.buckets.(BucketPersister)
+	if !ok {
+		return errors.New("the bucket manager does not support importing buckets")
+	}
+	return persister.Import(data, maxAge)
+}
+
+// BucketsSnapshot describes the rate limit buckets, see BucketInspector.
+func (c *Client) BucketsSnapshot() (*BucketsSnapshot, error) {
+	inspector, ok := c.buckets.(BucketInspector)
+	if !ok {
+		return nil, errors.New("the bucket manager does not support inspecting buckets")
+	}
+	return inspector.Snapshot(), nil
+}
+
+// EstimateWait predicts how long a request must wait for the rate limits, see BucketInspector.
+func (c *Client) EstimateWait(method, endpoint string) (time.Duration, error) {
+	inspector, ok := c.buckets.(BucketInspector)
+	if !ok {
+		return 0, errors.New("the bucket manager does not support inspecting buckets")
+	}
+	r := &Request{Method: method, Endpoint: endpoint}
+	r.PopulateMissing()
+	return inspector.EstimateWait(r.hashedEndpoint), nil
+}
+
 // SupportsDiscordAPIVersion check if a given discord api version is supported by this package.
 func SupportsDiscordAPIVersion(version int) bool {
 	supports := []int{
@@ -150,6 +231,11 @@ func NewClient(conf *Config) (*Client, error) {
 		return nil, errors.New("both a source(url) and a version must be present for sending requests to the Discord REST API")
 	}
 
+	baseURL := BaseURL
+	if conf.BaseURL != "" {
+		baseURL = strings.TrimSuffix(conf.BaseURL, "/")
+	}
+
 	// setup the required http request header fields
 	authorization := fmt.Sprintf(AuthorizationFormat, conf.BotToken)
 	userAgent := fmt.Sprintf(UserAgentFormat, conf.UserAgentSourceURL, conf.UserAgentVersion, conf.UserAgentExtra)
@@ -158,13 +244,21 @@ func NewClient(conf *Config) (*Client, error) {
 		"User-Agent":      {userAgent},
 		"Accept-Encoding": {"gzip"},
 	}
+	if conf.ProxySecret != "" {
+		header[ProxySecretHeader] = []string{conf.ProxySecret}
+	}
 
-	return &Client{
-		url:        BaseURL + "/v" + strconv.Itoa(conf.APIVersion),
+	client := &Client{
+		url:        baseURL + "/v" + strconv.Itoa(conf.APIVersion),
 		reqHeader:  header,
 		httpClient: conf.HttpClient,
 		buckets:    conf.RESTBucketManager,
-	}, nil
+		retry:      conf.RetryPolicy,
+
+		invalidRequests: newInvalidRequests(conf.InvalidRequestPolicy),
+	}
+	client.roundTrip = chainInterceptors(conf.Interceptors, client.sendHTTP)
+	return client, nil
 }
 
 // Config is the configuration options for the httd.Client structure. Essentially the behaviour of all requests
@@ -173,6 +267,12 @@ type Config struct {
 	APIVersion int
 	BotToken   string
 
+	// BaseURL replaces the Discord API URL, such as for a REST proxy. Defaults to httd.BaseURL.
+	BaseURL string
+
+	// ProxySecret is sent in the ProxySecretHeader field of every request, for a Proxy that requires it.
+	ProxySecret string
+
 	HttpClient HttpClientDoer
 
 	CancelRequestWhenRateLimited bool
@@ -180,6 +280,16 @@ type Config struct {
 	// RESTBucketManager stores all rate limit buckets and dictates the behaviour of how rate limiting is respected
 	RESTBucketManager RESTBucketManager
 
+	// RetryPolicy decides which failed requests are sent again. Nil disables retries.
+	RetryPolicy *RetryPolicy
+
+	// InvalidRequestPolicy decides what happens as responses with the status code 401, 403 and 429 are counted,
+	// such as opening a circuit breaker before Discord bans the IP address. See InvalidRequestPolicy.
+	InvalidRequestPolicy *InvalidRequestPolicy
+
+	// Interceptors wrap every request sent to Discord, in the given order. See Interceptor.
+	Interceptors []Interceptor
+
 	// Header field: `User-Agent: DiscordBot ({Source}, {Version}) {Extra}`
 	UserAgentVersion   string
 	UserAgentSourceURL string
@@ -241,46 +351,28 @@ func (c *Client) Do(ctx context.Context, r *Request) (resp *http.Response, body
 		}
 	}
 
-	// create http request
-	req, err := http.NewRequestWithContext(ctx, r.Method, c.url+r.Endpoint, r.bodyReader)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	header := copyHeader(c.reqHeader)
-	header.Set(ContentType, r.ContentType)
-	if r.Reason != "" {
-		header.Add(XAuditLogReason, r.Reason)
-	} else {
-		// the header is a map, so it's a shared memory resource
-		req.Header.Del(XAuditLogReason)
+	// the body is read again for every retry
+	var payload []byte
+	if r.bodyReader != nil && c.retry.enabled() {
+		if payload, err = ioutil.ReadAll(r.bodyReader); err != nil {
+			return nil, nil, err
+		}
 	}
-	req.Header = header
-
-	// queue & send request
-	c.buckets.Bucket(r.hashedEndpoint, func(bucket RESTBucket) {
-		resp, body, err = bucket.Transaction(ctx, func() (*http.Response, []byte, error) {
-			resp, err := c.httpClient.Do(req)
-			if err != nil {
-				return nil, nil, err
-			}
-
-			// store the current timestamp
-			epochMs := time.Now().UnixNano() / int64(time.Millisecond)
-			resp.Header.Set(XDisgordNow, strconv.FormatInt(epochMs, 10))
 
-			// decode body
-			body, err := c.decodeResponseBody(resp)
-			_ = resp.Body.Close()
-			if err != nil {
-				return nil, nil, err
-			}
+	for attempt := 1; ; attempt++ {
+		bodyReader := r.bodyReader
+		if payload != nil {
+			bodyReader = bytes.NewReader(payload)
+		}
 
-			// normalize Discord header fields
-			resp.Header, err = NormalizeDiscordHeader(resp.StatusCode, resp.Header, body)
-			return resp, body, err
-		})
-	})
+		resp, body, err = c.send(ctx, r, bodyReader, attempt)
+		if !c.retry.retry(ctx, attempt, r.Method, resp, err) {
+			break
+		}
+		if err = c.retry.wait(ctx, attempt); err != nil {
+			return nil, nil, err
+		}
+	}
 	if err != nil {
 		return nil, nil, err
 	}
@@ -293,7 +385,7 @@ func (c *Client) Do(ctx context.Context, r *Request) (resp *http.Response, body
 		msg := "response was not within the successful http code range [200, 300). code: "
 		msg += strconv.Itoa(resp.StatusCode)
 
-		err = &ErrREST{
+		restErr := &ErrREST{
 			Msg:            msg,
 			Suggestion:     string(body),
 			HTTPCode:       resp.StatusCode,
@@ -303,14 +395,110 @@ func (c *Client) Do(ctx context.Context, r *Request) (resp *http.Response, body
 
 		// store the Discord error if it exists
 		if len(body) > 0 {
-			_ = json.Unmarshal(body, err)
+			restErr.decodeDiscordError(body)
 		}
-		return nil, nil, err
+		return nil, nil, restErr
 	}
 
 	return resp, body, nil
 }
 
+// send makes a single attempt at the request, through the rate limit bucket of the endpoint.
+func (c *Client) send(ctx context.Context, r *Request, bodyReader io.Reader, attempt int) (resp *http.Response, body []byte, err error) {
+	if err = c.invalidRequests.check(); err != nil {
+		return nil, nil, err
+	}
+
+	// create http request
+	req, err := http.NewRequestWithContext(ctx, r.Method, c.url+r.Endpoint, bodyReader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	header := copyHeader(c.reqHeader)
+	header.Set(ContentType, r.ContentType)
+	if r.Reason != "" {
+		header.Add(XAuditLogReason, r.Reason)
+	} else {
+		// the header is a map, so it's a shared memory resource
+		req.Header.Del(XAuditLogReason)
+	}
+	req.Header = header
+
+	x := &Exchange{
+		Request:        r,
+		HTTPRequest:    req,
+		HashedEndpoint: r.hashedEndpoint,
+		Bucket:         r.hashedEndpoint,
+		Attempt:        attempt,
+		Queued:         time.Now(),
+	}
+
+	// queue & send request
+	c.buckets.Bucket(r.hashedEndpoint, func(bucket RESTBucket) {
+		resp, body, err = bucket.Transaction(WithPriority(ctx, r.Priority), func() (*http.Response, []byte, error) {
+			if err := c.roundTrip(x); err != nil {
+				return nil, nil, err
+			}
+			return c.interceptedResponse(x)
+		})
+	})
+	return resp, body, err
+}
+
+// interceptedResponse returns the response of the exchange, which an interceptor may have set without sending
+// the request.
+func (c *Client) interceptedResponse(x *Exchange) (*http.Response, []byte, error) {
+	if x.Response == nil {
+		return nil, nil, errors.New("the interceptors returned without a response for " + x.Request.Method + " " + x.Request.Endpoint)
+	}
+	if x.Response.Header == nil {
+		x.Response.Header = http.Header{}
+	}
+	if x.Response.Header.Get(DisgordNormalizedHeader) == "" {
+		header, err := NormalizeDiscordHeader(x.Response.StatusCode, x.Response.Header, x.Body)
+		if err != nil {
+			return nil, nil, err
+		}
+		x.Response.Header = header
+	}
+	return x.Response, x.Body, nil
+}
+
+// sendHTTP is the last step of the interceptor chain, and sends the http request to Discord.
+func (c *Client) sendHTTP(x *Exchange) error {
+	x.Sent = time.Now()
+	resp, err := c.httpClient.Do(x.HTTPRequest)
+	if err != nil {
+		return err
+	}
+
+	// store the current timestamp
+	epochMs := time.Now().UnixNano() / int64(time.Millisecond)
+	resp.Header.Set(XDisgordNow, strconv.FormatInt(epochMs, 10))
+
+	// decode body
+	body, err := c.decodeResponseBody(resp)
+	_ = resp.Body.Close()
+	x.Duration = time.Since(x.Sent)
+	if err != nil {
+		return err
+	}
+	if err = c.invalidRequests.add(resp, body); err != nil {
+		return err
+	}
+
+	// normalize Discord header fields
+	if resp.Header, err = NormalizeDiscordHeader(resp.StatusCode, resp.Header, body); err != nil {
+		return err
+	}
+	if bucket := resp.Header.Get(XRateLimitBucket); bucket != "" {
+		x.Bucket = bucket
+	}
+	x.Response, x.Body = resp, body
+	return nil
+}
+
 // helper functions
 func convertStructToIOReader(marshal func(v interface{}) ([]byte, error), v interface{}) (io.Reader, error) {
 	jsonParamsBytes, err := marshal(v)
@@ -1,6 +1,12 @@
 package httd
 
-import "time"
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/andersfylling/disgord/json"
+)
 
 type Error struct {
 	message string
@@ -16,3 +22,59 @@ func (e *Error) Error() string {
 var (
 	ErrRateLimited error = &Error{"rate limited", time.Unix(0, 0)}
 )
+
+// APIErrorCode is the JSON error code Discord returns together with a failed request.
+// It implements error, so codes can be used as sentinel errors with errors.Is against a *ErrREST.
+//
+// https://discord.com/developers/docs/topics/opcodes-and-status-codes#json-json-error-codes
+type APIErrorCode int
+
+var _ error = APIErrorCode(0)
+
+func (c APIErrorCode) Error() string {
+	return "discord api error code " + strconv.Itoa(int(c))
+}
+
+// FieldError describes why a single field of the request was rejected.
+type FieldError struct {
+	// Path to the field, such as "embeds.0.fields.1.name". Empty for errors about the request as a whole.
+	Path    string `json:"-"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// flattenFieldErrors walks the nested "errors" object of a Discord error response and returns every
+// error found, ordered by path.
+func flattenFieldErrors(path string, data json.RawMessage, errs []FieldError) []FieldError {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return errs
+	}
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if key == "_errors" {
+			var fieldErrs []FieldError
+			if err := json.Unmarshal(object[key], &fieldErrs); err != nil {
+				continue
+			}
+			for i := range fieldErrs {
+				fieldErrs[i].Path = path
+			}
+			errs = append(errs, fieldErrs...)
+			continue
+		}
+
+		subPath := key
+		if path != "" {
+			subPath = path + "." + key
+		}
+		errs = flattenFieldErrors(subPath, object[key], errs)
+	}
+	return errs
+}
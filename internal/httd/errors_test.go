@@ -0,0 +1,59 @@
+//go:build !integration
+// +build !integration
+
+package httd
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrREST_decodeDiscordError(t *testing.T) {
+	body := []byte(`{
+		"code": 50035,
+		"message": "Invalid Form Body",
+		"errors": {
+			"embeds": {"0": {"fields": {"1": {"name": {"_errors": [{"code": "BASE_TYPE_REQUIRED", "message": "This field is required"}]}}}}},
+			"content": {"_errors": [{"code": "BASE_TYPE_MAX_LENGTH", "message": "Must be 2000 or fewer in length."}]}
+		}
+	}`)
+
+	restErr := &ErrREST{Msg: "original"}
+	restErr.decodeDiscordError(body)
+
+	if restErr.Code != 50035 {
+		t.Errorf("expected code 50035, got %d", restErr.Code)
+	}
+	if restErr.Msg != "Invalid Form Body" {
+		t.Errorf("expected the discord message, got %q", restErr.Msg)
+	}
+
+	expected := []FieldError{
+		{Path: "content", Code: "BASE_TYPE_MAX_LENGTH", Message: "Must be 2000 or fewer in length."},
+		{Path: "embeds.0.fields.1.name", Code: "BASE_TYPE_REQUIRED", Message: "This field is required"},
+	}
+	if len(restErr.Errors) != len(expected) {
+		t.Fatalf("expected %d field errors, got %+v", len(expected), restErr.Errors)
+	}
+	for i := range expected {
+		if restErr.Errors[i] != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], restErr.Errors[i])
+		}
+	}
+}
+
+func TestErrREST_Is(t *testing.T) {
+	const unknownMessage, missingAccess APIErrorCode = 10008, 50001
+
+	err := fmt.Errorf("wrapped: %w", &ErrREST{Code: int(unknownMessage)})
+	if !errors.Is(err, unknownMessage) {
+		t.Error("expected error to match the error code")
+	}
+	if errors.Is(err, missingAccess) {
+		t.Error("expected error to not match a different error code")
+	}
+	if errors.Is(&ErrREST{}, APIErrorCode(0)) {
+		t.Error("a missing error code must not match")
+	}
+}
@@ -19,6 +19,7 @@ const (
 	XRateLimitReset         = "X-RateLimit-Reset"
 	XRateLimitResetAfter    = "X-RateLimit-Reset-After"
 	XRateLimitGlobal        = "X-RateLimit-Global"
+	XRateLimitScope         = "X-RateLimit-Scope"
 	RateLimitRetryAfter     = "Retry-After"
 	DisgordNormalizedHeader = "X-Disgord-Normalized-Kufdsfksduhf-S47yf"
 	XDisgordNow             = "X-Disgord-Now-fsagkhf"
@@ -0,0 +1,65 @@
+package httd
+
+import (
+	"net/http"
+	"time"
+)
+
+// Exchange is a single attempt at sending a REST request, as seen by the interceptors.
+type Exchange struct {
+	Request     *Request
+	HTTPRequest *http.Request
+
+	// HashedEndpoint is the local rate limit bucket of the request, see Request.HashEndpoint. Bucket is the
+	// Discord bucket hash given in the response, or the hashed endpoint when Discord did not specify one.
+	HashedEndpoint string
+	Bucket         string
+
+	// Attempt counts from 1, and is above 1 when the request is retried
+	Attempt int
+
+	// Queued is when the request started waiting on the rate limit bucket, and Sent when the request was handed
+	// to the http client. Duration is the time from Sent until the response body was read.
+	Queued   time.Time
+	Sent     time.Time
+	Duration time.Duration
+
+	// Response and Body are set once the next interceptor returns without an error, or by an interceptor that
+	// does not call next.
+	Response *http.Response
+	Body     []byte
+}
+
+// RoundTrip sends the exchange and populates the response and body.
+type RoundTrip func(x *Exchange) error
+
+// Interceptor wraps every REST request to Discord, similar to a http.RoundTripper. Interceptors are called
+// once per attempt after the rate limit bucket has been acquired, in the order they were configured, and must
+// call next to send the request. They can modify the http request before calling next, and inspect the
+// response, body and timing afterwards.
+//
+// An interceptor may return without calling next, such as to mock or serve a cached response, but must then set
+// Response and Body itself. Returning no error and no Response fails the request.
+type Interceptor interface {
+	Intercept(x *Exchange, next RoundTrip) error
+}
+
+// InterceptorFunc allows a function to be used as an Interceptor.
+type InterceptorFunc func(x *Exchange, next RoundTrip) error
+
+func (f InterceptorFunc) Intercept(x *Exchange, next RoundTrip) error {
+	return f(x, next)
+}
+
+var _ Interceptor = (InterceptorFunc)(nil)
+
+// chainInterceptors wraps the round trip such that the first interceptor is called first.
+func chainInterceptors(interceptors []Interceptor, send RoundTrip) RoundTrip {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], send
+		send = func(x *Exchange) error {
+			return interceptor.Intercept(x, next)
+		}
+	}
+	return send
+}
@@ -0,0 +1,142 @@
+//go:build !integration
+// +build !integration
+
+package httd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestClient_Do_interceptors(t *testing.T) {
+	var calls []string
+	record := func(name string) Interceptor {
+		return InterceptorFunc(func(x *Exchange, next RoundTrip) error {
+			calls = append(calls, name+":before")
+			x.HTTPRequest.Header.Add("X-Trace", name)
+			err := next(x)
+			calls = append(calls, name+":after")
+			return err
+		})
+	}
+
+	var audited *Exchange
+	audit := InterceptorFunc(func(x *Exchange, next RoundTrip) error {
+		err := next(x)
+		audited = x
+		return err
+	})
+
+	client, err := NewClient(&Config{
+		APIVersion: 9,
+		BotToken:   "testing",
+		HttpClient: doerFunc(func(req *http.Request) (*http.Response, error) {
+			if traces := req.Header["X-Trace"]; !reflect.DeepEqual(traces, []string{"a", "b"}) {
+				t.Errorf("expected headers set by interceptors, got %v", traces)
+			}
+			resp := emptyResponse(req, http.StatusNoContent)
+			resp.Header.Set(XRateLimitBucket, "abcd")
+			return resp, nil
+		}),
+		Interceptors:       []Interceptor{record("a"), record("b"), audit},
+		UserAgentSourceURL: "https://github.com/andersfylling/disgord",
+		UserAgentVersion:   "v0",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := &Request{Method: http.MethodDelete, Endpoint: "/channels/1/messages/2", Reason: "spam"}
+	if _, _, err = client.Do(context.Background(), r); err != nil {
+		t.Fatal(err)
+	}
+
+	expects := []string{"a:before", "b:before", "b:after", "a:after"}
+	if !reflect.DeepEqual(calls, expects) {
+		t.Errorf("expected interceptors to be called in order %v, got %v", expects, calls)
+	}
+
+	if audited == nil {
+		t.Fatal("expected the exchange to be intercepted")
+	}
+	if audited.Request.Reason != "spam" || audited.HashedEndpoint != r.HashEndpoint() {
+		t.Errorf("unexpected request details %+v", audited)
+	}
+	if audited.Bucket != "abcd" || audited.Attempt != 1 {
+		t.Errorf("expected bucket abcd on the first attempt, got %s on attempt %d", audited.Bucket, audited.Attempt)
+	}
+	if audited.Response.StatusCode != http.StatusNoContent || audited.Sent.Before(audited.Queued) {
+		t.Errorf("unexpected response or timing %+v", audited)
+	}
+}
+
+func TestClient_Do_interceptorError(t *testing.T) {
+	denied := errors.New("denied")
+	client, err := NewClient(&Config{
+		APIVersion: 9,
+		BotToken:   "testing",
+		HttpClient: doerFunc(func(req *http.Request) (*http.Response, error) {
+			t.Error("expected the request to not be sent")
+			return emptyResponse(req, http.StatusOK), nil
+		}),
+		Interceptors: []Interceptor{InterceptorFunc(func(x *Exchange, next RoundTrip) error {
+			return denied
+		})},
+		UserAgentSourceURL: "https://github.com/andersfylling/disgord",
+		UserAgentVersion:   "v0",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err = client.Do(context.Background(), &Request{Endpoint: "/users/@me"}); !errors.Is(err, denied) {
+		t.Errorf("expected the interceptor error, got %v", err)
+	}
+}
+
+func TestClient_Do_interceptorShortCircuit(t *testing.T) {
+	newClient := func(interceptor InterceptorFunc) *Client {
+		client, err := NewClient(&Config{
+			APIVersion: 9,
+			BotToken:   "testing",
+			HttpClient: doerFunc(func(req *http.Request) (*http.Response, error) {
+				t.Error("expected the request to not be sent")
+				return emptyResponse(req, http.StatusOK), nil
+			}),
+			Interceptors:       []Interceptor{interceptor},
+			UserAgentSourceURL: "https://github.com/andersfylling/disgord",
+			UserAgentVersion:   "v0",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return client
+	}
+
+	t.Run("response", func(t *testing.T) {
+		client := newClient(func(x *Exchange, next RoundTrip) error {
+			x.Response = &http.Response{StatusCode: http.StatusOK, Request: x.HTTPRequest}
+			x.Body = []byte(`{"id":"1"}`)
+			return nil
+		})
+		_, body, err := client.Do(context.Background(), &Request{Endpoint: "/users/@me"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(body) != `{"id":"1"}` {
+			t.Errorf("expected the body set by the interceptor, got %s", body)
+		}
+	})
+
+	t.Run("no response", func(t *testing.T) {
+		client := newClient(func(x *Exchange, next RoundTrip) error {
+			return nil
+		})
+		if _, _, err := client.Do(context.Background(), &Request{Endpoint: "/users/@me"}); err == nil {
+			t.Error("expected an error when no response was set")
+		}
+	})
+}
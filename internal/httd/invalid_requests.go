@@ -0,0 +1,173 @@
+package httd
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Discord temporarily bans the IP address of clients that send too many invalid requests, meaning
+// responses with the status code 401, 403 or 429, within a time window.
+//
+// https://discord.com/developers/docs/topics/rate-limits#invalid-request-limit-aka-cloudflare-bans
+const (
+	InvalidRequestLimit  = 10000
+	InvalidRequestWindow = 10 * time.Minute
+)
+
+// how long requests fail fast after a Cloudflare ban, when the response does not say
+const cloudflareBanDuration = time.Hour
+
+// InvalidRequestPolicy decides what happens as invalid requests are counted.
+type InvalidRequestPolicy struct {
+	// WarnAt are the invalid request counts at which Warn is called.
+	WarnAt []int
+	Warn   func(invalidRequests int)
+
+	// BreakAt opens the circuit breaker once the invalid request count reaches it, which makes every request
+	// fail with a *ErrCircuitOpen until the count has dropped below it again. 0 disables the circuit breaker.
+	// Regardless of BreakAt, requests fail with a *ErrCircuitOpen after a Cloudflare ban, until the ban is over.
+	BreakAt int
+}
+
+// ErrCircuitOpen is returned, without sending the request, while the circuit breaker is open.
+type ErrCircuitOpen struct {
+	InvalidRequests int
+
+	// CloudflareBan is true when the circuit breaker was opened by a Cloudflare ban, which lasts until Until.
+	CloudflareBan bool
+	Until         time.Time
+}
+
+func (e *ErrCircuitOpen) Error() string {
+	if e.CloudflareBan {
+		return "the REST circuit breaker is open due to a Cloudflare ban, until " + e.Until.Format(time.RFC3339)
+	}
+	return "the REST circuit breaker is open after " + strconv.Itoa(e.InvalidRequests) + " invalid requests within " +
+		InvalidRequestWindow.String()
+}
+
+// ErrCloudflareBan is returned for responses sent by Cloudflare instead of Discord, while the IP address is banned
+// for sending too many invalid requests.
+type ErrCloudflareBan struct {
+	Until time.Time
+}
+
+func (e *ErrCloudflareBan) Error() string {
+	return "the IP address is banned by Cloudflare for too many invalid requests, until " + e.Until.Format(time.RFC3339)
+}
+
+// invalidRequests counts invalid requests within InvalidRequestWindow, per second.
+type invalidRequests struct {
+	mu      sync.Mutex
+	policy  InvalidRequestPolicy
+	counts  [int(InvalidRequestWindow / time.Second)]int
+	seconds [int(InvalidRequestWindow / time.Second)]int64
+	banned  time.Time
+}
+
+func newInvalidRequests(policy *InvalidRequestPolicy) *invalidRequests {
+	counter := &invalidRequests{}
+	if policy != nil {
+		counter.policy = *policy
+	}
+	return counter
+}
+
+// count returns the number of invalid requests within the window. The lock must be held.
+func (c *invalidRequests) count(now time.Time) (count int) {
+	since := now.Unix() - int64(len(c.seconds))
+	for i := range c.counts {
+		if c.seconds[i] > since {
+			count += c.counts[i]
+		}
+	}
+	return count
+}
+
+// Count returns the number of invalid requests within InvalidRequestWindow.
+func (c *invalidRequests) Count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.count(time.Now())
+}
+
+// check returns a *ErrCircuitOpen while the circuit breaker is open, or while Cloudflare bans the IP address.
+func (c *invalidRequests) check() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if c.banned.After(now) {
+		return &ErrCircuitOpen{InvalidRequests: c.count(now), CloudflareBan: true, Until: c.banned}
+	}
+	if c.policy.BreakAt <= 0 {
+		return nil
+	}
+	if count := c.count(now); count >= c.policy.BreakAt {
+		return &ErrCircuitOpen{InvalidRequests: count}
+	}
+	return nil
+}
+
+// add counts the response if it is invalid. A Cloudflare ban is returned as a *ErrCloudflareBan.
+func (c *invalidRequests) add(resp *http.Response, body []byte) error {
+	if !isInvalidRequest(resp) {
+		return nil
+	}
+
+	now := time.Now()
+	second := now.Unix()
+	i := int(second % int64(len(c.seconds)))
+
+	c.mu.Lock()
+	if c.seconds[i] != second {
+		c.seconds[i] = second
+		c.counts[i] = 0
+	}
+	c.counts[i]++
+	count := c.count(now)
+
+	var err error
+	if isCloudflareBan(resp, body) {
+		c.banned = now.Add(cloudflareBanDuration)
+		if retryAfter, parseErr := strconv.ParseFloat(resp.Header.Get(RateLimitRetryAfter), 64); parseErr == nil && retryAfter > 0 {
+			c.banned = now.Add(time.Duration(retryAfter * float64(time.Second)))
+		}
+		err = &ErrCloudflareBan{Until: c.banned}
+	}
+	c.mu.Unlock()
+
+	if c.policy.Warn != nil {
+		for _, threshold := range c.policy.WarnAt {
+			if count == threshold {
+				c.policy.Warn(count)
+			}
+		}
+	}
+	return err
+}
+
+func isInvalidRequest(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return true
+	case http.StatusTooManyRequests:
+		return resp.Header.Get(XRateLimitScope) != "shared"
+	default:
+		return false
+	}
+}
+
+// isCloudflareBan reports whether the response comes from Cloudflare instead of Discord, which happens
+// when the IP address has been banned. Such responses lack the rate limit headers and JSON body of Discord.
+func isCloudflareBan(resp *http.Response, body []byte) bool {
+	if resp.StatusCode != http.StatusTooManyRequests {
+		return false
+	}
+	if resp.Header.Get(XRateLimitBucket) != "" || resp.Header.Get(XRateLimitGlobal) != "" || resp.Header.Get(XRateLimitScope) != "" {
+		return false
+	}
+	return !strings.HasPrefix(resp.Header.Get(ContentType), ContentTypeJSON) || strings.Contains(string(body), "error code: 1015")
+}
@@ -0,0 +1,141 @@
+//go:build !integration
+// +build !integration
+
+package httd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestClient_Do_invalidRequests(t *testing.T) {
+	var warnings []int
+	var sent int
+	var resp func(req *http.Request) *http.Response
+	client, err := NewClient(&Config{
+		APIVersion: 9,
+		BotToken:   "testing",
+		HttpClient: doerFunc(func(req *http.Request) (*http.Response, error) {
+			sent++
+			return resp(req), nil
+		}),
+		InvalidRequestPolicy: &InvalidRequestPolicy{
+			WarnAt:  []int{2, 3},
+			Warn:    func(invalidRequests int) { warnings = append(warnings, invalidRequests) },
+			BreakAt: 3,
+		},
+		UserAgentSourceURL: "https://github.com/andersfylling/disgord",
+		UserAgentVersion:   "v0",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	do := func() error {
+		_, _, err := client.Do(context.Background(), &Request{Endpoint: "/guilds/1"})
+		return err
+	}
+
+	resp = func(req *http.Request) *http.Response {
+		r := emptyResponse(req, http.StatusTooManyRequests)
+		r.Header.Set(XRateLimitScope, "shared")
+		r.Header.Set(XRateLimitResetAfter, "0.001")
+		return r
+	}
+	_ = do()
+	if client.InvalidRequests() != 0 {
+		t.Errorf("expected shared rate limits to not be counted, got %d", client.InvalidRequests())
+	}
+
+	resp = func(req *http.Request) *http.Response {
+		return emptyResponse(req, http.StatusForbidden)
+	}
+	for i := 0; i < 3; i++ {
+		var restErr *ErrREST
+		if err := do(); !errors.As(err, &restErr) {
+			t.Fatalf("expected a forbidden error, got %v", err)
+		}
+	}
+	if client.InvalidRequests() != 3 {
+		t.Errorf("expected 3 invalid requests, got %d", client.InvalidRequests())
+	}
+	if !reflect.DeepEqual(warnings, []int{2, 3}) {
+		t.Errorf("expected warnings at 2 and 3 invalid requests, got %v", warnings)
+	}
+
+	var circuitErr *ErrCircuitOpen
+	if err := do(); !errors.As(err, &circuitErr) || circuitErr.InvalidRequests != 3 || circuitErr.CloudflareBan {
+		t.Fatalf("expected the circuit breaker to be open, got %v", err)
+	}
+	if sent != 4 {
+		t.Errorf("expected no request to be sent while the circuit breaker is open, got %d", sent)
+	}
+
+	// invalid requests are forgotten after the window
+	client.invalidRequests.mu.Lock()
+	for i := range client.invalidRequests.seconds {
+		client.invalidRequests.seconds[i] -= int64(InvalidRequestWindow / time.Second)
+	}
+	client.invalidRequests.mu.Unlock()
+	if client.InvalidRequests() != 0 {
+		t.Errorf("expected the invalid requests to expire, got %d", client.InvalidRequests())
+	}
+
+	resp = func(req *http.Request) *http.Response {
+		r := emptyResponse(req, http.StatusTooManyRequests)
+		r.Header.Set(ContentType, "text/plain")
+		r.Header.Set(RateLimitRetryAfter, "60")
+		r.Body = ioutil.NopCloser(bytes.NewReader([]byte("error code: 1015")))
+		return r
+	}
+	var banErr *ErrCloudflareBan
+	if err := do(); !errors.As(err, &banErr) || time.Until(banErr.Until) > time.Minute {
+		t.Fatalf("expected a Cloudflare ban for a minute, got %v", err)
+	}
+	if err := do(); !errors.As(err, &circuitErr) || !circuitErr.CloudflareBan {
+		t.Errorf("expected the circuit breaker to be open during the ban, got %v", err)
+	}
+}
+
+func TestClient_Do_cloudflareBanWithoutCircuitBreaker(t *testing.T) {
+	var sent int
+	client, err := NewClient(&Config{
+		APIVersion: 9,
+		BotToken:   "testing",
+		HttpClient: doerFunc(func(req *http.Request) (*http.Response, error) {
+			sent++
+			r := emptyResponse(req, http.StatusTooManyRequests)
+			r.Header.Set(ContentType, "text/plain")
+			r.Header.Set(RateLimitRetryAfter, "60")
+			r.Body = ioutil.NopCloser(bytes.NewReader([]byte("error code: 1015")))
+			return r, nil
+		}),
+		InvalidRequestPolicy: &InvalidRequestPolicy{},
+		UserAgentSourceURL:   "https://github.com/andersfylling/disgord",
+		UserAgentVersion:     "v0",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	do := func() error {
+		_, _, err := client.Do(context.Background(), &Request{Endpoint: "/guilds/1"})
+		return err
+	}
+
+	var banErr *ErrCloudflareBan
+	if err := do(); !errors.As(err, &banErr) {
+		t.Fatalf("expected a Cloudflare ban, got %v", err)
+	}
+	var circuitErr *ErrCircuitOpen
+	if err := do(); !errors.As(err, &circuitErr) || !circuitErr.CloudflareBan {
+		t.Errorf("expected requests to fail fast during the ban, got %v", err)
+	}
+	if sent != 1 {
+		t.Errorf("expected no request to be sent during the ban, got %d", sent)
+	}
+}
@@ -0,0 +1,30 @@
+package httd
+
+import (
+	"context"
+)
+
+// Priority decides the order in which requests waiting for the same rate limit bucket, or for the global
+// rate limit, are sent. Requests of low priority are raised over time, such that they are never starved.
+type Priority int
+
+const (
+	PriorityLow    Priority = -1
+	PriorityNormal Priority = 0
+	PriorityHigh   Priority = 1
+)
+
+type priorityKey struct{}
+
+// WithPriority returns a context that carries the priority of a request to the rate limit bucket.
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// PriorityFromContext returns the priority set by WithPriority, or PriorityNormal.
+func PriorityFromContext(ctx context.Context) Priority {
+	if priority, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return priority
+	}
+	return PriorityNormal
+}
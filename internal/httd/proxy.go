@@ -0,0 +1,88 @@
+package httd
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// ProxySecretHeader holds the shared secret of requests sent to a Proxy.
+const ProxySecretHeader = "X-Disgord-Proxy-Secret"
+
+// Proxy serves the Discord REST API over HTTP, and forwards every request through a single Client. Processes
+// using the same bot token can send their requests through one proxy to share the rate limit buckets, instead
+// of each keeping their own. The Authorization header of incoming requests is replaced by the bot token of the
+// client.
+//
+// The paths follow the Discord API, such that a client only needs to replace the base URL. Eg. a proxy listening
+// on localhost:8080 serves GET https://discord.com/api/v9/users/@me at GET http://localhost:8080/api/v9/users/@me.
+//
+// Anyone able to reach the proxy can act as the bot. When a secret is given, requests without the secret in the
+// ProxySecretHeader field are rejected, see Config.ProxySecret.
+type Proxy struct {
+	client *Client
+	prefix string // eg. /api/v9
+	secret string
+}
+
+var _ http.Handler = (*Proxy)(nil)
+
+// NewProxy creates a proxy for the API version of the client. An empty secret accepts every request.
+func NewProxy(client *Client, secret string) *Proxy {
+	prefix := "/api"
+	if u, err := url.Parse(client.url); err == nil {
+		prefix += "/" + path.Base(u.Path)
+	}
+	return &Proxy{client: client, prefix: prefix, secret: secret}
+}
+
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if p.secret != "" && subtle.ConstantTimeCompare([]byte(req.Header.Get(ProxySecretHeader)), []byte(p.secret)) != 1 {
+		http.Error(w, "missing or invalid "+ProxySecretHeader+" header", http.StatusUnauthorized)
+		return
+	}
+
+	endpoint := req.URL.EscapedPath()
+	if !strings.HasPrefix(endpoint, p.prefix+"/") {
+		http.Error(w, "requests must be prefixed with "+p.prefix, http.StatusNotFound)
+		return
+	}
+	endpoint = strings.TrimPrefix(endpoint, p.prefix)
+	if req.URL.RawQuery != "" {
+		endpoint += "?" + req.URL.RawQuery
+	}
+
+	r := &Request{
+		Method:      req.Method,
+		Endpoint:    endpoint,
+		ContentType: req.Header.Get(ContentType),
+		Reason:      req.Header.Get(XAuditLogReason),
+	}
+	if req.ContentLength != 0 {
+		r.Body = req.Body
+	}
+
+	resp, body, err := p.client.Do(req.Context(), r)
+	if err != nil {
+		var restErr *ErrREST
+		if !errors.As(err, &restErr) {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+
+		// forward the Discord error as is
+		w.Header().Set(ContentType, ContentTypeJSON)
+		w.WriteHeader(restErr.HTTPCode)
+		_, _ = w.Write([]byte(restErr.Suggestion))
+		return
+	}
+
+	if contentType := resp.Header.Get(ContentType); contentType != "" {
+		w.Header().Set(ContentType, contentType)
+	}
+	w.WriteHeader(resp.StatusCode)
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,123 @@
+//go:build !integration
+// +build !integration
+
+package httd
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestProxy(t *testing.T) {
+	var requests []string
+	discord := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		requests = append(requests, req.Method+" "+req.URL.RequestURI()+" "+string(body))
+
+		if auth := req.Header.Get("Authorization"); auth != "Bot proxy-token" {
+			t.Errorf("expected the proxy token, got %q", auth)
+		}
+		if reason := req.Header.Get(XAuditLogReason); req.Method == http.MethodPatch && reason != "cleanup" {
+			t.Errorf("expected the audit log reason to be forwarded, got %q", reason)
+		}
+
+		w.Header().Set(ContentType, ContentTypeJSON)
+		if strings.HasSuffix(req.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code": 10003, "message": "Unknown Channel"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer discord.Close()
+
+	conf := func(token, baseURL, secret string, buckets RESTBucketManager) *Config {
+		return &Config{
+			APIVersion:         9,
+			BotToken:           token,
+			BaseURL:            baseURL,
+			ProxySecret:        secret,
+			HttpClient:         &http.Client{},
+			RESTBucketManager:  buckets,
+			UserAgentSourceURL: "https://github.com/andersfylling/disgord",
+			UserAgentVersion:   "v0",
+		}
+	}
+
+	upstream, err := NewClient(conf("proxy-token", discord.URL+"/api", "", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy := httptest.NewServer(NewProxy(upstream, "secret"))
+	defer proxy.Close()
+
+	worker, err := NewClient(conf("worker-token", proxy.URL+"/api/", "secret", NewPassthroughManager()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	_, body, err := worker.Do(ctx, &Request{Endpoint: "/guilds/1/members?limit=10"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != `{"id":"1"}` {
+		t.Errorf("unexpected body %s", body)
+	}
+
+	_, _, err = worker.Do(ctx, &Request{
+		Method:      http.MethodPatch,
+		Endpoint:    "/channels/1",
+		Body:        map[string]string{"name": "general"},
+		ContentType: ContentTypeJSON,
+		Reason:      "cleanup",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = worker.Do(ctx, &Request{Endpoint: "/channels/missing"})
+	var restErr *ErrREST
+	if !errors.As(err, &restErr) || restErr.HTTPCode != http.StatusNotFound || restErr.Code != 10003 {
+		t.Errorf("expected the Discord error to be forwarded, got %v", err)
+	}
+
+	expects := []string{
+		"GET /api/v9/guilds/1/members?limit=10 ",
+		`PATCH /api/v9/channels/1 {"name":"general"}`,
+		"GET /api/v9/channels/missing ",
+	}
+	if strings.Join(requests, "\n") != strings.Join(expects, "\n") {
+		t.Errorf("expected requests\n%s\ngot\n%s", strings.Join(expects, "\n"), strings.Join(requests, "\n"))
+	}
+
+	get := func(path, secret string) int {
+		req, err := http.NewRequest(http.MethodGet, proxy.URL+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set(ProxySecretHeader, secret)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode
+	}
+	if code := get("/api/v8/users/@me", "secret"); code != http.StatusNotFound {
+		t.Errorf("expected other API versions to be rejected, got %d", code)
+	}
+	for _, secret := range []string{"", "wrong"} {
+		if code := get("/api/v9/users/@me", secret); code != http.StatusUnauthorized {
+			t.Errorf("expected the secret %q to be rejected, got %d", secret, code)
+		}
+	}
+	if len(requests) != len(expects) {
+		t.Errorf("expected rejected requests to not be forwarded, got %d requests", len(requests))
+	}
+}
@@ -25,6 +25,9 @@ type Request struct {
 	// Reason is a X-Audit-Log-Reason header field that will show up on the audit log for this action.
 	Reason string
 
+	// Priority decides the order of requests waiting for the same rate limit bucket.
+	Priority Priority
+
 	bodyReader     io.Reader
 	hashedEndpoint string
 }
@@ -0,0 +1,124 @@
+package httd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy decides which failed requests are sent again. Every attempt goes through the rate limit bucket
+// of the request, so rate limits are respected as usual. A 429 response is never retried by the policy, as
+// it is handled by the buckets.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first. Retries are disabled below 2.
+	MaxAttempts int
+
+	// MinBackoff is the wait before the first retry. It is doubled for every following retry, up to MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// Jitter is the fraction of the backoff that is randomized, between 0 and 1. With a jitter of 0.2 the
+	// wait is between 80% and 100% of the backoff, which spreads out retries from concurrent requests.
+	Jitter float64
+
+	// StatusCodes are the HTTP status codes that are retried, such as 502, 503 and 504.
+	StatusCodes []int
+
+	// RetryError decides if an error from the http client, such as a connection reset or timeout, is
+	// retried. Nil retries network errors, see IsTransientNetworkError.
+	RetryError func(err error) bool
+
+	// RetryNonIdempotent allows POST and PATCH requests to be retried. A request that failed after reaching
+	// Discord might have been applied, so retrying it can eg. send a message twice.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy retries idempotent requests up to three times on bad gateway, service unavailable and
+// gateway timeout responses, and on network errors.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  5 * time.Second,
+		Jitter:      0.2,
+		StatusCodes: []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+	}
+}
+
+// IsTransientNetworkError reports whether the error is a timeout, connection reset, refused connection or
+// unexpected end of a response, which are often solved by sending the request again.
+func IsTransientNetworkError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return isConnectionErrno(err) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
+
+func (p *RetryPolicy) enabled() bool {
+	return p != nil && p.MaxAttempts > 1
+}
+
+func idempotent(method string) bool {
+	return method != http.MethodPost && method != http.MethodPatch
+}
+
+// retry reports whether the given attempt, counting from 1, should be followed by another.
+func (p *RetryPolicy) retry(ctx context.Context, attempt int, method string, resp *http.Response, err error) bool {
+	if !p.enabled() || attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return false
+	}
+	if !p.RetryNonIdempotent && !idempotent(method) {
+		return false
+	}
+
+	if err != nil {
+		retryError := p.RetryError
+		if retryError == nil {
+			retryError = IsTransientNetworkError
+		}
+		return retryError(err)
+	}
+	for _, code := range p.StatusCodes {
+		if resp.StatusCode == code && code != http.StatusTooManyRequests {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns how long to wait before the retry that follows the given attempt.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	backoff := p.MinBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || backoff < p.MaxBackoff); i++ {
+		backoff *= 2
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+
+	if p.Jitter > 0 {
+		jitter := p.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+		backoff -= time.Duration(rand.Float64() * jitter * float64(backoff))
+	}
+	return backoff
+}
+
+// wait blocks until the next attempt may be sent, or the context is done.
+func (p *RetryPolicy) wait(ctx context.Context, attempt int) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(p.backoff(attempt)):
+		return nil
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !plan9
+// +build !plan9
+
+package httd
+
+import (
+	"errors"
+	"syscall"
+)
+
+// isConnectionErrno reports whether the error is a connection reset, refused connection or broken pipe.
+func isConnectionErrno(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE)
+}
@@ -0,0 +1,10 @@
+//go:build plan9
+// +build plan9
+
+package httd
+
+// isConnectionErrno reports false, as the platform has no errno values for connection errors. Timeouts and
+// unexpected ends of a response are still retried.
+func isConnectionErrno(err error) bool {
+	return false
+}
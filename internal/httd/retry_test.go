@@ -0,0 +1,185 @@
+//go:build !integration
+// +build !integration
+
+package httd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type doerFunc func(req *http.Request) (*http.Response, error)
+
+func (d doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return d(req)
+}
+
+func newRetryTestClient(t *testing.T, policy *RetryPolicy, doer doerFunc) *Client {
+	client, err := NewClient(&Config{
+		APIVersion:         9,
+		BotToken:           "testing",
+		HttpClient:         doer,
+		RetryPolicy:        policy,
+		UserAgentSourceURL: "https://github.com/andersfylling/disgord",
+		UserAgentVersion:   "v0",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func emptyResponse(req *http.Request, code int) *http.Response {
+	return &http.Response{
+		StatusCode: code,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+		Request:    req,
+	}
+}
+
+func TestClient_Do_retry(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	policy.MinBackoff = time.Millisecond
+
+	t.Run("retries-status-code", func(t *testing.T) {
+		var bodies []string
+		client := newRetryTestClient(t, policy, func(req *http.Request) (*http.Response, error) {
+			body, _ := ioutil.ReadAll(req.Body)
+			bodies = append(bodies, string(body))
+			if len(bodies) < 3 {
+				return emptyResponse(req, http.StatusServiceUnavailable), nil
+			}
+			return emptyResponse(req, http.StatusOK), nil
+		})
+
+		_, _, err := client.Do(context.Background(), &Request{
+			Method:      http.MethodPut,
+			Endpoint:    "/guilds/1/bans/2",
+			Body:        map[string]int{"delete_message_days": 1},
+			ContentType: ContentTypeJSON,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(bodies) != 3 {
+			t.Fatalf("expected 3 attempts, got %d", len(bodies))
+		}
+		for _, body := range bodies {
+			if body != bodies[0] || body == "" {
+				t.Errorf("expected every attempt to send the same body, got %q", bodies)
+			}
+		}
+	})
+
+	t.Run("gives-up", func(t *testing.T) {
+		var attempts int
+		client := newRetryTestClient(t, policy, func(req *http.Request) (*http.Response, error) {
+			attempts++
+			return emptyResponse(req, http.StatusBadGateway), nil
+		})
+
+		_, _, err := client.Do(context.Background(), &Request{Method: http.MethodGet, Endpoint: "/users/@me"})
+		var restErr *ErrREST
+		if !errors.As(err, &restErr) || restErr.HTTPCode != http.StatusBadGateway {
+			t.Fatalf("expected a bad gateway error, got %v", err)
+		}
+		if attempts != policy.MaxAttempts {
+			t.Errorf("expected %d attempts, got %d", policy.MaxAttempts, attempts)
+		}
+	})
+
+	t.Run("non-idempotent", func(t *testing.T) {
+		var attempts int
+		client := newRetryTestClient(t, policy, func(req *http.Request) (*http.Response, error) {
+			attempts++
+			return nil, syscall.ECONNRESET
+		})
+
+		_, _, err := client.Do(context.Background(), &Request{Method: http.MethodPost, Endpoint: "/channels/1/messages"})
+		if !errors.Is(err, syscall.ECONNRESET) {
+			t.Fatalf("expected the connection error, got %v", err)
+		}
+		if attempts != 1 {
+			t.Errorf("expected POST requests to not be retried, got %d attempts", attempts)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		var attempts int
+		client := newRetryTestClient(t, nil, func(req *http.Request) (*http.Response, error) {
+			attempts++
+			return emptyResponse(req, http.StatusServiceUnavailable), nil
+		})
+
+		if _, _, err := client.Do(context.Background(), &Request{Method: http.MethodGet, Endpoint: "/users/@me"}); err == nil {
+			t.Fatal("expected an error")
+		}
+		if attempts != 1 {
+			t.Errorf("expected a single attempt, got %d", attempts)
+		}
+	})
+}
+
+func TestRetryPolicy_retry(t *testing.T) {
+	ctx := context.Background()
+	policy := DefaultRetryPolicy()
+	policy.StatusCodes = append(policy.StatusCodes, http.StatusTooManyRequests)
+
+	response := func(code int) *http.Response {
+		return &http.Response{StatusCode: code}
+	}
+
+	if !policy.retry(ctx, 1, http.MethodGet, response(http.StatusGatewayTimeout), nil) {
+		t.Error("expected a gateway timeout to be retried")
+	}
+	if policy.retry(ctx, 1, http.MethodGet, response(http.StatusTooManyRequests), nil) {
+		t.Error("rate limits are handled by the buckets and must not be retried")
+	}
+	if policy.retry(ctx, 1, http.MethodGet, response(http.StatusNotFound), nil) {
+		t.Error("expected a not found response to not be retried")
+	}
+	if policy.retry(ctx, policy.MaxAttempts, http.MethodGet, response(http.StatusGatewayTimeout), nil) {
+		t.Error("expected no retry after the last attempt")
+	}
+	if policy.retry(ctx, 1, http.MethodPatch, response(http.StatusGatewayTimeout), nil) {
+		t.Error("expected PATCH requests to not be retried")
+	}
+	if policy.retry(ctx, 1, http.MethodGet, nil, errors.New("malformed")) {
+		t.Error("expected only network errors to be retried")
+	}
+
+	policy.RetryNonIdempotent = true
+	if !policy.retry(ctx, 1, http.MethodPost, nil, syscall.ECONNRESET) {
+		t.Error("expected POST requests to be retried")
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if policy.retry(cancelled, 1, http.MethodGet, response(http.StatusGatewayTimeout), nil) {
+		t.Error("expected no retry once the context is done")
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	policy := &RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	expects := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, expect := range expects {
+		if got := policy.backoff(i + 1); got != expect {
+			t.Errorf("attempt %d: expected backoff %s, got %s", i+1, expect, got)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := policy.backoff(2); got < time.Second || got > 2*time.Second {
+			t.Fatalf("expected backoff within [1s, 2s], got %s", got)
+		}
+	}
+}
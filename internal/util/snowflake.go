@@ -1,6 +1,8 @@
 package util
 
 import (
+	"time"
+
 	"github.com/andersfylling/snowflake/v5"
 )
 
@@ -15,3 +17,12 @@ func GetSnowflake(v interface{}) (Snowflake, error) {
 func ParseSnowflakeString(v string) Snowflake {
 	return snowflake.ParseSnowflakeString(v)
 }
+
+// SnowflakeFromTime creates the lowest snowflake for the given timestamp
+func SnowflakeFromTime(t time.Time) Snowflake {
+	ms := uint64(t.UnixNano() / int64(time.Millisecond))
+	if ms < snowflake.EpochDiscord {
+		return 0
+	}
+	return Snowflake((ms - snowflake.EpochDiscord) << 22)
+}
@@ -2,6 +2,7 @@ package util
 
 import (
 	"sync"
+	"time"
 )
 
 type Ticket int
@@ -10,13 +11,35 @@ const (
 	NoTicket Ticket = -1
 )
 
+// PriorityAging is how long a ticket must wait to have its priority raised by one. This makes sure
+// tickets of low priority are served eventually, even when tickets of higher priority keep coming.
+const PriorityAging = 5 * time.Second
+
+// EffectivePriority returns the priority of a ticket created at the given time, raised by one for
+// every PriorityAging it has waited.
+func EffectivePriority(priority int, createdAt, now time.Time) int {
+	return priority + int(now.Sub(createdAt)/PriorityAging)
+}
+
+type queuedTicket struct {
+	ticket    Ticket
+	priority  int
+	createdAt time.Time
+}
+
+// TicketQueue serves tickets by priority, and in the order they were created when the priorities are equal.
 type TicketQueue struct {
 	mu         sync.Mutex
-	tickets    []Ticket
+	tickets    []queuedTicket
 	nextTicket Ticket
 }
 
 func (q *TicketQueue) NewTicket() (ticket Ticket) {
+	return q.NewPriorityTicket(0)
+}
+
+// NewPriorityTicket creates a ticket that is served before tickets of lower priority.
+func (q *TicketQueue) NewPriorityTicket(priority int) (ticket Ticket) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 	defer func() {
@@ -24,7 +47,11 @@ func (q *TicketQueue) NewTicket() (ticket Ticket) {
 	}()
 
 	ticket = q.nextTicket
-	q.tickets = append(q.tickets, ticket)
+	q.tickets = append(q.tickets, queuedTicket{
+		ticket:    ticket,
+		priority:  priority,
+		createdAt: time.Now(),
+	})
 
 	return ticket
 }
@@ -33,23 +60,25 @@ func (q *TicketQueue) Delete(ticket Ticket) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 
-	var i int
-	var ok bool
-	for i = range q.tickets {
-		if q.tickets[i] == ticket {
-			ok = true
-			break
+	for i := range q.tickets {
+		if q.tickets[i].ticket == ticket {
+			q.tickets = append(q.tickets[:i], q.tickets[i+1:]...)
+			return
 		}
 	}
-	if !ok {
-		return
-	}
+}
 
-	if i == len(q.tickets)-1 {
-		q.tickets = q.tickets[:i]
-	} else {
-		q.tickets = append(q.tickets[i:], q.tickets[i+1:]...)
+// next returns the index of the ticket to be served next.
+func (q *TicketQueue) next() int {
+	now := time.Now()
+	best, bestPriority := 0, EffectivePriority(q.tickets[0].priority, q.tickets[0].createdAt, now)
+	for i := 1; i < len(q.tickets); i++ {
+		// tickets are ordered by creation, so an equal priority is served later
+		if priority := EffectivePriority(q.tickets[i].priority, q.tickets[i].createdAt, now); priority > bestPriority {
+			best, bestPriority = i, priority
+		}
 	}
+	return best
 }
 
 func (q *TicketQueue) Next(ticket Ticket, cb func() bool) bool {
@@ -60,7 +89,8 @@ func (q *TicketQueue) Next(ticket Ticket, cb func() bool) bool {
 		return false
 	}
 
-	if q.tickets[0] != ticket {
+	i := q.next()
+	if q.tickets[i].ticket != ticket {
 		return false
 	}
 
@@ -68,10 +98,13 @@ func (q *TicketQueue) Next(ticket Ticket, cb func() bool) bool {
 		return false
 	}
 
-	if len(q.tickets) > 1 {
-		q.tickets = q.tickets[1:]
-	} else {
-		q.tickets = q.tickets[:0]
-	}
+	q.tickets = append(q.tickets[:i], q.tickets[i+1:]...)
 	return true
 }
+
+// Len returns the number of tickets in the queue.
+func (q *TicketQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.tickets)
+}
@@ -0,0 +1,59 @@
+//go:build !integration
+// +build !integration
+
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTicketQueue_priority(t *testing.T) {
+	q := &TicketQueue{}
+	low := q.NewPriorityTicket(-1)
+	normal := q.NewTicket()
+	high := q.NewPriorityTicket(1)
+	high2 := q.NewPriorityTicket(1)
+
+	accept := func() bool { return true }
+	for _, ticket := range []Ticket{high, high2, normal, low} {
+		for _, other := range []Ticket{low, normal, high2} {
+			if other != ticket && q.Next(other, accept) {
+				t.Fatalf("ticket %d was served before ticket %d", other, ticket)
+			}
+		}
+		if !q.Next(ticket, accept) {
+			t.Fatalf("expected ticket %d to be served", ticket)
+		}
+	}
+	if q.Len() != 0 {
+		t.Errorf("expected an empty queue, got %d tickets", q.Len())
+	}
+}
+
+func TestTicketQueue_aging(t *testing.T) {
+	q := &TicketQueue{}
+	low := q.NewPriorityTicket(-1)
+	q.tickets[0].createdAt = time.Now().Add(-3 * PriorityAging)
+	high := q.NewPriorityTicket(1)
+
+	if q.Next(high, func() bool { return true }) {
+		t.Error("expected the waiting ticket of low priority to be served first")
+	}
+	if !q.Next(low, func() bool { return true }) {
+		t.Error("expected the low priority ticket to be raised")
+	}
+}
+
+func TestTicketQueue_Delete(t *testing.T) {
+	q := &TicketQueue{}
+	a, b, c := q.NewTicket(), q.NewTicket(), q.NewTicket()
+	q.Delete(b)
+
+	if q.Len() != 2 {
+		t.Fatalf("expected 2 tickets, got %d", q.Len())
+	}
+	if !q.Next(a, func() bool { return true }) || !q.Next(c, func() bool { return true }) {
+		t.Error("expected the remaining tickets to be served in order")
+	}
+}
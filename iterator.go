@@ -0,0 +1,159 @@
+package disgord
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// PaginationDirection decides which way a iterator walks a list endpoint.
+type PaginationDirection int
+
+const (
+	// PaginateDefault uses the order the endpoint is usually read in. See the Iterate method for details.
+	PaginateDefault PaginationDirection = iota
+
+	// PaginateBackward walks from the highest ID towards the lowest, using the "before" query parameter.
+	PaginateBackward
+
+	// PaginateForward walks from the lowest ID towards the highest, using the "after" query parameter.
+	PaginateForward
+)
+
+// Pagination holds the settings shared by every iterator.
+type Pagination struct {
+	// PageSize is the number of items requested at the time. Zero, or a value above
+	// the maximum of the endpoint, uses the maximum.
+	PageSize int
+
+	Direction PaginationDirection
+
+	// Cursor is the exclusive ID to start from. Zero starts from the first item in the given direction.
+	// For lists ordered by time rather than ID, use SnowflakeFromTime.
+	Cursor Snowflake
+}
+
+// pageFetcher requests a single page. Only one of before and after is set.
+type pageFetcher func(ctx context.Context, before, after Snowflake, limit int) (items []interface{}, err error)
+
+// cursorIterator holds the pagination logic for the typed iterators. Every page is a REST request that is
+// subject to the rate limiter, and is sorted so the cursor does not depend on the order Discord returned it in.
+type cursorIterator struct {
+	fetch pageFetcher
+	id    func(item interface{}) Snowflake
+	until func(item interface{}) bool
+
+	pageSize  int
+	direction PaginationDirection
+	cursor    Snowflake
+
+	// poll keeps a forward iterator alive once caught up, by requesting a new page every poll duration
+	poll     time.Duration
+	caughtUp bool
+
+	err    error
+	done   bool
+	buffer []interface{}
+}
+
+func newCursorIterator(p Pagination, defaultDirection PaginationDirection, pageSizeMax int, fetch pageFetcher, id func(interface{}) Snowflake) *cursorIterator {
+	it := &cursorIterator{
+		fetch:     fetch,
+		id:        id,
+		pageSize:  p.PageSize,
+		direction: p.Direction,
+		cursor:    p.Cursor,
+	}
+	if it.pageSize <= 0 || it.pageSize > pageSizeMax {
+		it.pageSize = pageSizeMax
+	}
+	if it.direction == PaginateDefault {
+		it.direction = defaultDirection
+	}
+	return it
+}
+
+// only returns a iterator that fails on the first call to Next when the direction is not supported by the endpoint.
+func (it *cursorIterator) only(direction PaginationDirection) *cursorIterator {
+	if it.direction != direction {
+		it.err = ErrUnsupportedPaginationDirection
+	}
+	return it
+}
+
+func (it *cursorIterator) next(ctx context.Context) (interface{}, error) {
+	if it.err != nil {
+		return nil, it.err
+	}
+
+	for len(it.buffer) == 0 {
+		if it.done {
+			return nil, ErrIteratorDone
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if err := it.nextPage(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	item := it.buffer[0]
+	it.buffer = it.buffer[1:]
+	if it.until != nil && it.until(item) {
+		it.done = true
+		it.buffer = nil
+		return nil, ErrIteratorDone
+	}
+	return item, nil
+}
+
+func (it *cursorIterator) nextPage(ctx context.Context) error {
+	if it.caughtUp {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(it.poll):
+		}
+	}
+
+	var before, after Snowflake
+	if it.direction == PaginateForward {
+		after = it.cursor
+		if after.IsZero() {
+			// a zero value is dropped from the query, which makes some endpoints return the newest items
+			after = 1
+		}
+	} else {
+		before = it.cursor
+	}
+
+	items, err := it.fetch(ctx, before, after, it.pageSize)
+	if err != nil {
+		return err
+	}
+
+	forward := it.direction == PaginateForward
+	sort.SliceStable(items, func(i, j int) bool {
+		if forward {
+			return it.id(items[i]) < it.id(items[j])
+		}
+		return it.id(items[i]) > it.id(items[j])
+	})
+	// items without an ID must not reset the cursor
+	for i := len(items) - 1; i >= 0; i-- {
+		if id := it.id(items[i]); !id.IsZero() {
+			it.cursor = id
+			break
+		}
+	}
+
+	lastPage := len(items) < it.pageSize
+	if forward && it.poll > 0 {
+		it.caughtUp = lastPage
+	} else {
+		it.done = lastPage
+	}
+	it.buffer = items
+	return nil
+}
@@ -0,0 +1,249 @@
+//go:build !integration
+// +build !integration
+
+package disgord
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// snowflakePages returns a page fetcher over the IDs 11 to 10+n, newest first, and records the requested cursors
+func snowflakePages(n int, cursors *[]Snowflake) pageFetcher {
+	const first = 11
+	last := first + n - 1
+	return func(ctx context.Context, before, after Snowflake, limit int) ([]interface{}, error) {
+		var items []interface{}
+		if !after.IsZero() {
+			*cursors = append(*cursors, after)
+			id := int(after) + 1
+			if id < first {
+				id = first
+			}
+			for ; id <= last && len(items) < limit; id++ {
+				items = append([]interface{}{Snowflake(id)}, items...)
+			}
+			return items, nil
+		}
+
+		*cursors = append(*cursors, before)
+		if before.IsZero() {
+			before = Snowflake(last + 1)
+		}
+		for id := int(before) - 1; id >= first && len(items) < limit; id-- {
+			items = append(items, Snowflake(id))
+		}
+		return items, nil
+	}
+}
+
+func snowflakeID(item interface{}) Snowflake {
+	return item.(Snowflake)
+}
+
+func collect(t *testing.T, it *cursorIterator) (ids []Snowflake) {
+	for {
+		item, err := it.next(context.Background())
+		if errors.Is(err, ErrIteratorDone) {
+			return ids
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, item.(Snowflake))
+	}
+}
+
+func equalSnowflakes(a, b []Snowflake) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCursorIterator(t *testing.T) {
+	t.Run("backward", func(t *testing.T) {
+		var cursors []Snowflake
+		it := newCursorIterator(Pagination{PageSize: 2}, PaginateBackward, 100, snowflakePages(5, &cursors), snowflakeID)
+		ids := collect(t, it)
+		if expected := []Snowflake{15, 14, 13, 12, 11}; !equalSnowflakes(ids, expected) {
+			t.Errorf("expected %v, got %v", expected, ids)
+		}
+		if expected := []Snowflake{0, 14, 12}; !equalSnowflakes(cursors, expected) {
+			t.Errorf("expected cursors %v, got %v", expected, cursors)
+		}
+	})
+
+	t.Run("forward", func(t *testing.T) {
+		var cursors []Snowflake
+		it := newCursorIterator(Pagination{PageSize: 2, Direction: PaginateForward}, PaginateBackward, 100, snowflakePages(5, &cursors), snowflakeID)
+		ids := collect(t, it)
+		if expected := []Snowflake{11, 12, 13, 14, 15}; !equalSnowflakes(ids, expected) {
+			t.Errorf("expected %v, got %v", expected, ids)
+		}
+		if expected := []Snowflake{1, 12, 14}; !equalSnowflakes(cursors, expected) {
+			t.Errorf("expected cursors %v, got %v", expected, cursors)
+		}
+	})
+
+	t.Run("cursor", func(t *testing.T) {
+		var cursors []Snowflake
+		it := newCursorIterator(Pagination{Cursor: 14}, PaginateBackward, 100, snowflakePages(5, &cursors), snowflakeID)
+		ids := collect(t, it)
+		if expected := []Snowflake{13, 12, 11}; !equalSnowflakes(ids, expected) {
+			t.Errorf("expected %v, got %v", expected, ids)
+		}
+	})
+
+	t.Run("until", func(t *testing.T) {
+		var cursors []Snowflake
+		it := newCursorIterator(Pagination{PageSize: 2}, PaginateForward, 100, snowflakePages(5, &cursors), snowflakeID)
+		it.until = func(item interface{}) bool {
+			return item.(Snowflake) == 13
+		}
+		ids := collect(t, it)
+		if expected := []Snowflake{11, 12}; !equalSnowflakes(ids, expected) {
+			t.Errorf("expected %v, got %v", expected, ids)
+		}
+		if len(cursors) != 2 {
+			t.Errorf("expected the iterator to stop after 2 requests, got %d", len(cursors))
+		}
+	})
+
+	t.Run("unsupported direction", func(t *testing.T) {
+		var cursors []Snowflake
+		it := newCursorIterator(Pagination{Direction: PaginateBackward}, PaginateForward, 100, snowflakePages(5, &cursors), snowflakeID)
+		if _, err := it.only(PaginateForward).next(context.Background()); !errors.Is(err, ErrUnsupportedPaginationDirection) {
+			t.Errorf("expected ErrUnsupportedPaginationDirection, got %v", err)
+		}
+		if len(cursors) > 0 {
+			t.Error("no request should be sent")
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		var cursors []Snowflake
+		it := newCursorIterator(Pagination{}, PaginateBackward, 100, snowflakePages(5, &cursors), snowflakeID)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := it.next(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	})
+}
+
+func TestGuildScheduledEvent_IterateMembers(t *testing.T) {
+	pages := []string{
+		`[{"user":{"id":"12"}},null,{"member":{"user":{"id":"14"}}}]`,
+		`[]`,
+	}
+	var requests int
+	client := newMockedClient(t, doerMock(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/api/v9/users/@me" {
+			return jsonResponse(req, http.StatusOK, &User{ID: 1})
+		}
+		if requests >= len(pages) {
+			t.Fatalf("unexpected request %s", req.URL)
+		}
+		resp, err := jsonResponse(req, http.StatusOK, nil)
+		resp.Body = ioutil.NopCloser(strings.NewReader(pages[requests]))
+		requests++
+		return resp, err
+	}))
+
+	it := client.Guild(1).ScheduledEvent(2).IterateMembers(&IterateScheduledEventMembers{
+		Pagination: Pagination{PageSize: 3},
+	})
+	var ids []Snowflake
+	for {
+		user, err := it.Next(context.Background())
+		if errors.Is(err, ErrIteratorDone) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if user.User.ID.IsZero() {
+			ids = append(ids, user.Member.User.ID)
+		} else {
+			ids = append(ids, user.User.ID)
+		}
+	}
+	if expected := []Snowflake{12, 14}; !equalSnowflakes(ids, expected) {
+		t.Errorf("expected users without a user object and null entries to be handled, got %v", ids)
+	}
+	if requests != 2 {
+		t.Errorf("expected a null entry to count towards a full page, got %d requests", requests)
+	}
+}
+
+func TestChannel_IteratePublicArchivedThreads(t *testing.T) {
+	base := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	thread := func(id Snowflake, archivedAt time.Time) *Channel {
+		return &Channel{ID: id, ThreadMetadata: ThreadMetadata{Archived: true, ArchiveTimestamp: Time{archivedAt}}}
+	}
+	// two threads were archived in the same millisecond, and are split over two pages
+	threads := []*Channel{
+		thread(1, base.Add(3*time.Second)),
+		thread(2, base.Add(2*time.Second)),
+		thread(3, base.Add(2*time.Second)),
+		thread(4, base.Add(time.Second)),
+	}
+
+	var befores []string
+	client := newMockedClient(t, doerMock(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/api/v9/users/@me" {
+			return jsonResponse(req, http.StatusOK, &User{ID: 1})
+		}
+		query := req.URL.Query()
+		befores = append(befores, query.Get("before"))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+
+		// like Discord, threads archived at the before timestamp are returned again
+		page := &ArchivedThreads{}
+		for _, thread := range threads {
+			archivedAt := thread.ThreadMetadata.ArchiveTimestamp
+			if before := query.Get("before"); before != "" && archivedAt.String() > before {
+				continue
+			}
+			if len(page.Threads) == limit {
+				page.HasMore = true
+				break
+			}
+			page.Threads = append(page.Threads, thread)
+		}
+		return jsonResponse(req, http.StatusOK, page)
+	}))
+
+	it := client.Channel(1).IteratePublicArchivedThreads(&IterateArchivedThreads{
+		Pagination: Pagination{PageSize: 2},
+	})
+	var ids []Snowflake
+	for {
+		thread, err := it.Next(context.Background())
+		if errors.Is(err, ErrIteratorDone) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, thread.ID)
+	}
+	if expected := []Snowflake{1, 2, 3, 4}; !equalSnowflakes(ids, expected) {
+		t.Errorf("expected every thread once, got %v", ids)
+	}
+	if len(befores) < 2 || befores[1] != (Time{base.Add(2 * time.Second)}).String() {
+		t.Errorf("expected the archive timestamp as the cursor, got %v", befores)
+	}
+}
@@ -93,6 +93,10 @@ func (g guildMemberQueryBuilder) Update(params *UpdateMember) (*Member, error) {
 		return nil, err
 	}
 
+	if err := g.client.preflight(g.gid, 0, params.requiredPermissions(g.uid == g.client.botID)); err != nil {
+		return nil, err
+	}
+
 	r := g.client.newRESTRequest(&httd.Request{
 		Method:      http.MethodPatch,
 		Ctx:         g.ctx,
@@ -126,9 +130,41 @@ type UpdateMember struct {
 	AuditLogReason string `json:"-"`
 }
 
+// requiredPermissions returns the permissions needed to apply the update. The current user may change its
+// own nickname with the 'CHANGE_NICKNAME' permission.
+func (p *UpdateMember) requiredPermissions(self bool) (required PermissionBit) {
+	if p.Nick != nil {
+		if self {
+			required |= PermissionChangeNickname
+		} else {
+			required |= PermissionManageNicknames
+		}
+	}
+	if p.Roles != nil {
+		required |= PermissionManageRoles
+	}
+	if p.Mute != nil {
+		required |= PermissionVoiceMuteMembers
+	}
+	if p.Deaf != nil {
+		required |= PermissionVoiceDeafenMembers
+	}
+	if p.ChannelID != nil {
+		required |= PermissionVoiceMoveMembers
+	}
+	if p.CommunicationDisabledUntil != nil {
+		required |= PermissionTimeoutMembers
+	}
+	return required
+}
+
 // AddRole adds a role to a guild member. Requires the 'MANAGE_ROLES' permission.
 // Returns a 204 empty response on success. Fires a Guild Member Update Gateway event.
 func (g guildMemberQueryBuilder) AddRole(roleID Snowflake) error {
+	if err := g.client.preflight(g.gid, 0, PermissionManageRoles); err != nil {
+		return err
+	}
+
 	r := g.client.newRESTRequest(&httd.Request{
 		Method:   http.MethodPut,
 		Endpoint: endpoint.GuildMemberRole(g.gid, g.uid, roleID),
@@ -142,6 +178,10 @@ func (g guildMemberQueryBuilder) AddRole(roleID Snowflake) error {
 // RemoveRole removes a role from a guild member. Requires the 'MANAGE_ROLES' permission.
 // Returns a 204 empty response on success. Fires a Guild Member Update Gateway event.
 func (g guildMemberQueryBuilder) RemoveRole(roleID Snowflake) error {
+	if err := g.client.preflight(g.gid, 0, PermissionManageRoles); err != nil {
+		return err
+	}
+
 	r := g.client.newRESTRequest(&httd.Request{
 		Method:   http.MethodDelete,
 		Endpoint: endpoint.GuildMemberRole(g.gid, g.uid, roleID),
@@ -155,6 +195,10 @@ func (g guildMemberQueryBuilder) RemoveRole(roleID Snowflake) error {
 // Kick kicks a member from a guild. Requires 'KICK_MEMBERS' permission.
 // Returns a 204 empty response on success. Fires a Guild Member Remove Gateway event.
 func (g guildMemberQueryBuilder) Kick(reason string) error {
+	if err := g.client.preflight(g.gid, 0, PermissionKickMembers); err != nil {
+		return err
+	}
+
 	r := g.client.newRESTRequest(&httd.Request{
 		Method:   http.MethodDelete,
 		Endpoint: endpoint.GuildMember(g.gid, g.uid),
@@ -176,6 +220,10 @@ func (g guildMemberQueryBuilder) Ban(params *BanMember) (err error) {
 		return err
 	}
 
+	if err = g.client.preflight(g.gid, 0, PermissionBanMembers); err != nil {
+		return err
+	}
+
 	r := g.client.newRESTRequest(&httd.Request{
 		Method:   http.MethodPut,
 		Endpoint: endpoint.GuildBan(g.gid, g.uid) + params.URLQueryString(),
@@ -62,3 +62,28 @@ func TestUpdateMemberParams(t *testing.T) {
 		})
 	}
 }
+
+func TestMember_AvatarURL(t *testing.T) {
+	member := &Member{
+		GuildID: 1,
+		UserID:  2,
+		User:    &User{ID: 2, Avatar: "userhash"},
+	}
+
+	url, err := member.AvatarURL(64, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "https://cdn.discordapp.com/avatars/2/userhash.webp?size=64"; url != expected {
+		t.Errorf("expected user avatar fallback %q, got %q", expected, url)
+	}
+
+	member.Avatar = "a_memberhash"
+	url, err = member.AvatarURL(64, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "https://cdn.discordapp.com/guilds/1/users/2/avatars/a_memberhash.gif?size=64"; url != expected {
+		t.Errorf("expected guild avatar %q, got %q", expected, url)
+	}
+}
@@ -0,0 +1,226 @@
+package disgord
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// ContentTokenType is the kind of markup a ContentToken holds.
+type ContentTokenType int
+
+const (
+	ContentText ContentTokenType = iota
+	ContentUserMention
+	ContentRoleMention
+	ContentChannelMention
+	ContentEveryoneMention
+	ContentEmoji
+	ContentTimestamp
+	ContentMessageLink
+)
+
+// ContentToken is a piece of message content found by ParseContent.
+type ContentToken struct {
+	Type ContentTokenType
+
+	// Raw is the token as written in the content
+	Raw string
+
+	// ID of the mentioned user, role or channel, the custom emoji, or the linked message
+	ID Snowflake
+
+	// GuildID and ChannelID of a message link. The guild ID is zero for direct messages.
+	GuildID   Snowflake
+	ChannelID Snowflake
+
+	// Name and Animated describe a custom emoji
+	Name     string
+	Animated bool
+
+	// Time and Style describe a timestamp
+	Time  time.Time
+	Style TimestampStyle
+}
+
+// contentMarkup matches the markup of ParseContent. Code and backslash escapes are matched first, such that
+// the markup within them is kept as text.
+var contentMarkup = regexp.MustCompile("(```(?s:.*?)```|``(?s:.+?)``|`[^`]+`|\\\\[^\\w\\s])" +
+	`|<@!?(\d+)>` +
+	`|<@&(\d+)>` +
+	`|<#(\d+)>` +
+	`|@everyone|@here` +
+	`|<(a?):(\w{2,32}):(\d+)>` +
+	`|<t:(-?\d+)(?::([tTdDfFR]))?>` +
+	`|https://(?:(?:ptb|canary)\.)?discord(?:app)?\.com/channels/(\d+|@me)/(\d+)/(\d+)`)
+
+// submatch indexes of contentMarkup
+const (
+	matchText = 1 + iota
+	matchUser
+	matchRole
+	matchChannel
+	matchEmojiAnimated
+	matchEmojiName
+	matchEmojiID
+	matchTimestamp
+	matchTimestampStyle
+	matchLinkGuild
+	matchLinkChannel
+	matchLinkMessage
+)
+
+// ParseContent splits message content into text and the markup Discord renders: user, role and channel
+// mentions, @everyone and @here, custom emojis, timestamps and message links, such as the ones created
+// by Message.DiscordURL. Markup within inline code, code blocks or escaped by a backslash is text, as is markup
+// with an ID that does not fit a Snowflake. Joining the Raw fields of the tokens gives back the content.
+func ParseContent(content string) []*ContentToken {
+	var tokens []*ContentToken
+	addText := func(text string) {
+		if text != "" {
+			tokens = append(tokens, &ContentToken{Type: ContentText, Raw: text})
+		}
+	}
+
+	var last int
+	for _, match := range contentMarkup.FindAllStringSubmatchIndex(content, -1) {
+		token := &ContentToken{Raw: content[match[0]:match[1]]}
+		group := func(i int) string {
+			if match[2*i] < 0 {
+				return ""
+			}
+			return content[match[2*i]:match[2*i+1]]
+		}
+		valid := true
+		snowflake := func(i int) Snowflake {
+			id, err := strconv.ParseUint(group(i), 10, 64)
+			if err != nil {
+				valid = false
+			}
+			return Snowflake(id)
+		}
+
+		switch {
+		case group(matchText) != "":
+			continue
+		case group(matchUser) != "":
+			token.Type = ContentUserMention
+			token.ID = snowflake(matchUser)
+		case group(matchRole) != "":
+			token.Type = ContentRoleMention
+			token.ID = snowflake(matchRole)
+		case group(matchChannel) != "":
+			token.Type = ContentChannelMention
+			token.ID = snowflake(matchChannel)
+		case group(matchEmojiID) != "":
+			token.Type = ContentEmoji
+			token.ID = snowflake(matchEmojiID)
+			token.Name = group(matchEmojiName)
+			token.Animated = group(matchEmojiAnimated) != ""
+		case group(matchTimestamp) != "":
+			unix, err := strconv.ParseInt(group(matchTimestamp), 10, 64)
+			if err != nil {
+				continue // out of range, kept as text
+			}
+			token.Type = ContentTimestamp
+			token.Time = time.Unix(unix, 0)
+			token.Style = TimestampStyle(group(matchTimestampStyle))
+		case group(matchLinkMessage) != "":
+			token.Type = ContentMessageLink
+			if group(matchLinkGuild) != "@me" {
+				token.GuildID = snowflake(matchLinkGuild)
+			}
+			token.ChannelID = snowflake(matchLinkChannel)
+			token.ID = snowflake(matchLinkMessage)
+		default:
+			token.Type = ContentEveryoneMention
+		}
+		if !valid {
+			continue // out of range, kept as text
+		}
+
+		addText(content[last:match[0]])
+		tokens = append(tokens, token)
+		last = match[1]
+	}
+	addText(content[last:])
+	return tokens
+}
+
+// ParseContent tokenizes the message content, see ParseContent.
+func (m *Message) ParseContent() []*ContentToken {
+	return ParseContent(m.Content)
+}
+
+// MentionedIDs returns the unique IDs of every token of the given type, in the order they first appear.
+func MentionedIDs(tokens []*ContentToken, tokenType ContentTokenType) []Snowflake {
+	var ids []Snowflake
+	seen := make(map[Snowflake]bool)
+	for _, token := range tokens {
+		if token.Type == tokenType && !seen[token.ID] {
+			seen[token.ID] = true
+			ids = append(ids, token.ID)
+		}
+	}
+	return ids
+}
+
+// Emoji returns the custom emoji of a emoji token. Only the ID, name and animated fields are set.
+func (t *ContentToken) Emoji() (*Emoji, error) {
+	if t.Type != ContentEmoji {
+		return nil, ErrMissingEmojiID
+	}
+	return &Emoji{ID: t.ID, Name: t.Name, Animated: t.Animated}, nil
+}
+
+// User fetches the user of a user mention, from the cache when possible.
+func (t *ContentToken) User(ctx context.Context, s Session) (*User, error) {
+	if t.Type != ContentUserMention {
+		return nil, ErrMissingUserID
+	}
+	return s.User(t.ID).WithContext(ctx).Get()
+}
+
+// Member fetches the guild member of a user mention, from the cache when possible.
+func (t *ContentToken) Member(ctx context.Context, s Session, guildID Snowflake) (*Member, error) {
+	if t.Type != ContentUserMention {
+		return nil, ErrMissingUserID
+	}
+	return s.Guild(guildID).Member(t.ID).WithContext(ctx).Get()
+}
+
+// Role fetches the role of a role mention, from the cache when possible.
+func (t *ContentToken) Role(ctx context.Context, s Session, guildID Snowflake) (*Role, error) {
+	if t.Type != ContentRoleMention {
+		return nil, ErrMissingRoleID
+	}
+	return s.Guild(guildID).Role(t.ID).WithContext(ctx).Get()
+}
+
+// Channel fetches the channel of a channel mention or message link, from the cache when possible.
+func (t *ContentToken) Channel(ctx context.Context, s Session) (*Channel, error) {
+	switch t.Type {
+	case ContentChannelMention:
+		return s.Channel(t.ID).WithContext(ctx).Get()
+	case ContentMessageLink:
+		return s.Channel(t.ChannelID).WithContext(ctx).Get()
+	default:
+		return nil, ErrMissingChannelID
+	}
+}
+
+// Message fetches the message of a message link, from the cache when possible.
+func (t *ContentToken) Message(ctx context.Context, s Session) (*Message, error) {
+	if t.Type != ContentMessageLink {
+		return nil, ErrMissingMessageID
+	}
+	msg, err := s.Channel(t.ChannelID).Message(t.ID).WithContext(ctx).Get()
+	if err != nil {
+		return nil, err
+	}
+	if msg.GuildID.IsZero() {
+		msg.GuildID = t.GuildID
+	}
+	return msg, nil
+}
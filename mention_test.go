@@ -0,0 +1,113 @@
+//go:build !integration
+// +build !integration
+
+package disgord
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestParseContent(t *testing.T) {
+	content := "hi <@1> and <@!2>, <@&3> in <#4> @everyone <a:party:5> <:ok:6> at <t:1618953630:R> " +
+		"see https://discord.com/channels/7/8/9 and https://canary.discord.com/channels/@me/10/11 <t:99999999999999999999>"
+	tokens := ParseContent(content)
+
+	var raw strings.Builder
+	for _, token := range tokens {
+		raw.WriteString(token.Raw)
+	}
+	if raw.String() != content {
+		t.Fatalf("expected the tokens to hold the whole content, got %q", raw.String())
+	}
+
+	var markup []*ContentToken
+	for _, token := range tokens {
+		if token.Type != ContentText {
+			markup = append(markup, token)
+		}
+	}
+	expected := []ContentToken{
+		{Type: ContentUserMention, ID: 1},
+		{Type: ContentUserMention, ID: 2},
+		{Type: ContentRoleMention, ID: 3},
+		{Type: ContentChannelMention, ID: 4},
+		{Type: ContentEveryoneMention},
+		{Type: ContentEmoji, ID: 5, Name: "party", Animated: true},
+		{Type: ContentEmoji, ID: 6, Name: "ok"},
+		{Type: ContentTimestamp, Style: TimestampRelative},
+		{Type: ContentMessageLink, GuildID: 7, ChannelID: 8, ID: 9},
+		{Type: ContentMessageLink, ChannelID: 10, ID: 11},
+	}
+	if len(markup) != len(expected) {
+		t.Fatalf("expected %d tokens, got %d", len(expected), len(markup))
+	}
+	for i, e := range expected {
+		got := markup[i]
+		if got.Type != e.Type || got.ID != e.ID || got.GuildID != e.GuildID || got.ChannelID != e.ChannelID ||
+			got.Name != e.Name || got.Animated != e.Animated || got.Style != e.Style {
+			t.Errorf("token %d: expected %+v, got %+v", i, e, *got)
+		}
+	}
+	if markup[7].Time.Unix() != 1618953630 {
+		t.Errorf("unexpected timestamp %s", markup[7].Time)
+	}
+
+	ids := MentionedIDs(ParseContent("<@1> <@!1> <@2>"), ContentUserMention)
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Errorf("expected unique user IDs, got %v", ids)
+	}
+}
+
+func TestParseContent_text(t *testing.T) {
+	content := "`<@1>` and ``a ` <@2>`` in ```go\n<#3> @everyone\n``` or \\<@4> \\@here <@99999999999999999999> " +
+		"<#99999999999999999999> https://discord.com/channels/1/2/99999999999999999999 but <@5>"
+	tokens := ParseContent(content)
+
+	var raw strings.Builder
+	for _, token := range tokens {
+		raw.WriteString(token.Raw)
+	}
+	if raw.String() != content {
+		t.Fatalf("expected the tokens to hold the whole content, got %q", raw.String())
+	}
+	if len(tokens) != 2 || tokens[0].Type != ContentText || tokens[1].Type != ContentUserMention || tokens[1].ID != 5 {
+		t.Errorf("expected only the last mention to be markup, got %+v", tokens)
+	}
+}
+
+func TestContentToken_resolve(t *testing.T) {
+	client := newMockedClient(t, doerMock(func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/api/v9/users/1":
+			return jsonResponse(req, http.StatusOK, &User{ID: 1, Username: "anders"})
+		case "/api/v9/channels/8/messages/9":
+			return jsonResponse(req, http.StatusOK, &Message{ID: 9, ChannelID: 8})
+		}
+		t.Fatalf("unexpected request to %s", req.URL.Path)
+		return nil, nil
+	}))
+
+	tokens := ParseContent("<@1> https://discord.com/channels/7/8/9")
+	user, err := tokens[0].User(context.Background(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Username != "anders" {
+		t.Errorf("unexpected user %+v", user)
+	}
+
+	msg, err := tokens[2].Message(context.Background(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.ID != 9 || msg.GuildID != 7 {
+		t.Errorf("unexpected message %+v", msg)
+	}
+
+	if _, err = tokens[0].Message(context.Background(), client); err == nil {
+		t.Error("expected a user mention to not resolve to a message")
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/andersfylling/disgord/json"
 
+	"github.com/andersfylling/disgord/cdn"
 	"github.com/andersfylling/disgord/internal/endpoint"
 	"github.com/andersfylling/disgord/internal/httd"
 )
@@ -168,8 +169,20 @@ const (
 	MessageStickerFormatPNG
 	MessageStickerFormatAPNG
 	MessageStickerFormatLOTTIE
+	MessageStickerFormatGIF
 )
 
+func (f MessageStickerFormatType) cdnFormat() cdn.Format {
+	switch f {
+	case MessageStickerFormatLOTTIE:
+		return cdn.Lottie
+	case MessageStickerFormatGIF:
+		return cdn.GIF
+	default:
+		return cdn.PNG
+	}
+}
+
 type StickerItem struct {
 	ID         Snowflake                `json:"id"`
 	Name       string                   `json:"name"`
@@ -179,6 +192,12 @@ type StickerItem struct {
 var _ Copier = (*StickerItem)(nil)
 var _ DeepCopier = (*StickerItem)(nil)
 
+// URL returns a link to the sticker file. The size is ignored for lottie stickers.
+func (s *StickerItem) URL(size int) (string, error) {
+	format := s.FormatType.cdnFormat()
+	return cdn.Sticker(s.ID, format).URL(format, size)
+}
+
 type MessageSticker struct {
 	ID           Snowflake                `json:"id"`
 	PackID       Snowflake                `json:"pack_id"`
@@ -193,6 +212,12 @@ type MessageSticker struct {
 var _ Copier = (*MessageSticker)(nil)
 var _ DeepCopier = (*MessageSticker)(nil)
 
+// URL returns a link to the sticker file. The size is ignored for lottie stickers.
+func (s *MessageSticker) URL(size int) (string, error) {
+	format := s.FormatType.cdnFormat()
+	return cdn.Sticker(s.ID, format).URL(format, size)
+}
+
 // Message https://discord.com/developers/docs/resources/channel#message-object-message-structure
 type Message struct {
 	ID                Snowflake           `json:"id"`
@@ -505,6 +530,9 @@ type UpdateMessage struct {
 }
 
 func (p *UpdateMessage) prepare() (postBody interface{}, contentType string, err error) {
+	if err = p.FindErrors(); err != nil {
+		return nil, "", err
+	}
 	if p.File == nil {
 		return p, httd.ContentTypeJSON, nil
 	}
@@ -563,6 +591,14 @@ func (m messageQueryBuilder) Delete() (err error) {
 	if m.mid.IsZero() {
 		return ErrMissingMessageID
 	}
+	if err = m.client.preflight(0, m.cid, PermissionManageMessages); err != nil {
+		// the bot can always delete its own messages. The author is only looked up in the cache, and a message
+		// that is not cached is deleted without a check, like any other missing cache data.
+		msg, cacheErr := m.client.cache.GetMessage(m.cid, m.mid)
+		if cacheErr == nil && msg.Author != nil && msg.Author.ID != m.client.botID {
+			return err
+		}
+	}
 
 	r := m.client.newRESTRequest(&httd.Request{
 		Method:   http.MethodDelete,
@@ -583,6 +619,10 @@ func (m messageQueryBuilder) Delete() (err error) {
 //	Reviewed                2018-06-10
 //	Comment                 -
 func (m messageQueryBuilder) Pin() (err error) {
+	if err = m.client.preflight(0, m.cid, PermissionManageMessages); err != nil {
+		return err
+	}
+
 	r := m.client.newRESTRequest(&httd.Request{
 		Method:   http.MethodPut,
 		Endpoint: endpoint.ChannelPin(m.cid, m.mid),
@@ -609,6 +649,10 @@ func (m messageQueryBuilder) Unpin() (err error) {
 		return ErrMissingMessageID
 	}
 
+	if err = m.client.preflight(0, m.cid, PermissionManageMessages); err != nil {
+		return err
+	}
+
 	r := m.client.newRESTRequest(&httd.Request{
 		Method:   http.MethodDelete,
 		Endpoint: endpoint.ChannelPin(m.cid, m.mid),
@@ -715,6 +759,10 @@ func (m messageQueryBuilder) DeleteAllReactions() error {
 		return ErrMissingMessageID
 	}
 
+	if err := m.client.preflight(0, m.cid, PermissionManageMessages); err != nil {
+		return err
+	}
+
 	r := m.client.newRESTRequest(&httd.Request{
 		Method:   http.MethodDelete,
 		Endpoint: endpoint.ChannelMessageReactions(m.cid, m.mid),
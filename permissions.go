@@ -0,0 +1,308 @@
+package disgord
+
+import (
+	"fmt"
+	"time"
+)
+
+// permissionsAll is given to the guild owner and administrators. It holds every bit, including
+// permissions added to Discord after this library was released.
+const permissionsAll = ^PermissionBit(0)
+
+// permissionsTimedOut are the only permissions a member keeps while in a timeout.
+const permissionsTimedOut = PermissionViewChannel | PermissionReadMessageHistory
+
+// permissionsRequireSend are implicitly denied when the member can not send messages in the channel.
+const permissionsRequireSend = PermissionMentionEveryone | PermissionSendTTSMessages | PermissionAttachFiles | PermissionEmbedLinks
+
+func isThread(t ChannelType) bool {
+	return t == ChannelTypeGuildNewsThread || t == ChannelTypeGuildPublicThread || t == ChannelTypeGuildPrivateThread
+}
+
+// ComputeBasePermissions calculates the guild wide permissions of a member. The guild must hold the
+// @everyone role and the roles of the member. The guild owner and administrators are given every permission.
+//
+// https://discord.com/developers/docs/topics/permissions#permission-overwrites
+func ComputeBasePermissions(guild *Guild, member *Member) PermissionBit {
+	return computeBasePermissions(guild, member, time.Now())
+}
+
+func computeBasePermissions(guild *Guild, member *Member, now time.Time) PermissionBit {
+	if !guild.OwnerID.IsZero() && member.UserID == guild.OwnerID {
+		return permissionsAll
+	}
+
+	var permissions PermissionBit
+	for _, role := range guild.Roles {
+		if role == nil {
+			continue
+		}
+		if role.ID == guild.ID {
+			permissions |= role.Permissions
+			continue
+		}
+		for _, roleID := range member.Roles {
+			if roleID == role.ID {
+				permissions |= role.Permissions
+				break
+			}
+		}
+	}
+
+	if permissions.Contains(PermissionAdministrator) {
+		return permissionsAll
+	}
+	if isTimedOut(member, now) {
+		permissions &= permissionsTimedOut
+	}
+	return permissions
+}
+
+func isTimedOut(member *Member, now time.Time) bool {
+	return member.CommunicationDisabledUntil.After(now)
+}
+
+// ComputePermissions calculates the permissions of a member in a channel by applying the channel overwrites
+// in the order @everyone, roles and then member on top of the base permissions. Permissions that depend on
+// other permissions, such as sending messages without being able to view the channel, are removed.
+//
+// A thread uses the overwrites of its parent channel, which must be found in guild.Channels. Otherwise only
+// the base permissions are used. Membership of private threads is not verified. Use a nil channel to get
+// the base permissions.
+func ComputePermissions(guild *Guild, member *Member, channel *Channel) PermissionBit {
+	return computePermissions(guild, member, channel, time.Now())
+}
+
+func computePermissions(guild *Guild, member *Member, channel *Channel, now time.Time) PermissionBit {
+	permissions := computeBasePermissions(guild, member, now)
+	if channel == nil || permissions == permissionsAll {
+		return permissions
+	}
+
+	overwrites := channel.PermissionOverwrites
+	thread := isThread(channel.Type)
+	if thread {
+		overwrites = nil
+		for _, c := range guild.Channels {
+			if c != nil && c.ID == channel.ParentID {
+				overwrites = c.PermissionOverwrites
+				break
+			}
+		}
+	}
+
+	var roleAllow, roleDeny PermissionBit
+	var memberOverwrite *PermissionOverwrite
+	for i := range overwrites {
+		overwrite := &overwrites[i]
+		switch {
+		case overwrite.ID == guild.ID:
+			permissions &^= overwrite.Deny
+			permissions |= overwrite.Allow
+		case overwrite.Type == PermissionOverwriteMember:
+			if overwrite.ID == member.UserID {
+				memberOverwrite = overwrite
+			}
+		default:
+			for _, roleID := range member.Roles {
+				if roleID == overwrite.ID {
+					roleAllow |= overwrite.Allow
+					roleDeny |= overwrite.Deny
+					break
+				}
+			}
+		}
+	}
+	permissions &^= roleDeny
+	permissions |= roleAllow
+	if memberOverwrite != nil {
+		permissions &^= memberOverwrite.Deny
+		permissions |= memberOverwrite.Allow
+	}
+
+	if isTimedOut(member, now) {
+		permissions &= permissionsTimedOut
+	}
+
+	// implicit permissions
+	if !permissions.Contains(PermissionViewChannel) {
+		return 0
+	}
+	if thread {
+		// sending messages in a thread is decided by a separate permission
+		if permissions.Contains(PermissionSendMessagesInThreads) {
+			permissions |= PermissionSendMessages
+		} else {
+			permissions &^= PermissionSendMessages
+		}
+	}
+	if !permissions.Contains(PermissionSendMessages) {
+		permissions &^= permissionsRequireSend
+	}
+	return permissions
+}
+
+// roleAbove reports whether role a is placed above role b, using the ordering of the roles sorter.
+func roleAbove(a, b *Role) bool {
+	return roles{a, b}.Less(0, 1)
+}
+
+// HighestRole returns the highest role of the member in the guild. The @everyone role is returned when the
+// member has no other roles, and nil when none of the roles are found in guild.Roles.
+func (g *Guild) HighestRole(member *Member) *Role {
+	var highest *Role
+	for _, role := range g.Roles {
+		if role == nil {
+			continue
+		}
+		if role.ID != g.ID && !hasRole(member, role.ID) {
+			continue
+		}
+		if highest == nil || roleAbove(role, highest) {
+			highest = role
+		}
+	}
+	return highest
+}
+
+func hasRole(member *Member, roleID Snowflake) bool {
+	for _, id := range member.Roles {
+		if id == roleID {
+			return true
+		}
+	}
+	return false
+}
+
+// outranks reports whether the actor is above the target in the role hierarchy. The guild owner
+// outranks everyone, and can not be outranked.
+func (g *Guild) outranks(actor, target *Member) bool {
+	if target.UserID == g.OwnerID {
+		return false
+	}
+	if actor.UserID == g.OwnerID {
+		return true
+	}
+	actorRole, targetRole := g.HighestRole(actor), g.HighestRole(target)
+	if actorRole == nil {
+		return false
+	}
+	return targetRole == nil || roleAbove(actorRole, targetRole)
+}
+
+// CanManageMember returns nil when the actor can use the required permissions, such as PermissionKickMembers
+// or PermissionBanMembers, against the target. Discord requires the highest role of the actor to be above the
+// highest role of the target, even for administrators, and nobody can manage the guild owner.
+//
+// A *ErrMissingPermissions is returned when the actor lacks the required permissions, and an error wrapping
+// ErrRoleHierarchy when the target is placed too high. The guild must hold both members and their roles.
+func (g *Guild) CanManageMember(actorID, targetID Snowflake, required PermissionBit) error {
+	actor, err := g.Member(actorID)
+	if err != nil {
+		return err
+	}
+	target, err := g.Member(targetID)
+	if err != nil {
+		return err
+	}
+
+	if missing := required &^ ComputeBasePermissions(g, actor); missing != 0 {
+		return &ErrMissingPermissions{Required: missing, Guild: g.ID}
+	}
+	if !g.outranks(actor, target) {
+		if targetID == g.OwnerID {
+			return fmt.Errorf("the guild owner can not be managed: %w", ErrRoleHierarchy)
+		}
+		return fmt.Errorf("the highest role of member %s is not below the highest role of member %s: %w", targetID, actorID, ErrRoleHierarchy)
+	}
+	return nil
+}
+
+// CanAssignRole returns nil when the actor can add the role to, or remove it from, a member. This requires the
+// 'MANAGE_ROLES' permission and, unless the actor owns the guild, that the role is placed below the highest role
+// of the actor. The @everyone role and roles managed by an integration can never be assigned.
+//
+// A *ErrMissingPermissions is returned when the actor lacks the permission, and an error wrapping
+// ErrRoleHierarchy when the role is placed too high.
+func (g *Guild) CanAssignRole(actorID, roleID Snowflake) error {
+	actor, err := g.Member(actorID)
+	if err != nil {
+		return err
+	}
+	role, err := g.Role(roleID)
+	if err != nil {
+		return err
+	}
+	if role.ID == g.ID || role.Managed {
+		return fmt.Errorf("role %s can not be assigned to members: %w", roleID, ErrIllegalValue)
+	}
+
+	if !ComputeBasePermissions(g, actor).Contains(PermissionManageRoles) {
+		return &ErrMissingPermissions{Required: PermissionManageRoles, Guild: g.ID}
+	}
+	if actorID == g.OwnerID {
+		return nil
+	}
+	if highest := g.HighestRole(actor); highest == nil || !roleAbove(highest, role) {
+		return fmt.Errorf("role %s is not below the highest role of member %s: %w", roleID, actorID, ErrRoleHierarchy)
+	}
+	return nil
+}
+
+// CanEditChannel returns nil when the actor can update or delete the channel. This requires the 'MANAGE_CHANNELS'
+// permission in the channel, or 'MANAGE_THREADS' for threads, where ownership of the thread is not considered.
+// Changing the permission overwrites of the channel also requires 'MANAGE_ROLES', which is not verified.
+//
+// A *ErrMissingPermissions is returned when the actor lacks the permission. The channel must be found in
+// guild.Channels.
+func (g *Guild) CanEditChannel(actorID, channelID Snowflake) error {
+	actor, err := g.Member(actorID)
+	if err != nil {
+		return err
+	}
+	channel, err := g.Channel(channelID)
+	if err != nil {
+		return err
+	}
+
+	required := PermissionManageChannels
+	if isThread(channel.Type) {
+		required = PermissionManageThreads
+	}
+	if !ComputePermissions(g, actor, channel).Contains(required) {
+		return &ErrMissingPermissions{Required: required, Channel: channelID, Guild: g.ID}
+	}
+	return nil
+}
+
+// preflight returns a ErrMissingPermissions when the cache shows the bot lacks the required permissions in the
+// guild, or in the channel when a channel ID is given. Missing cache data never stops a request. The guild ID
+// of a channel is looked up in the cache when it is not known, and direct messages are never checked.
+func (c *Client) preflight(guildID, channelID Snowflake, required PermissionBit) error {
+	if !c.config.PreflightPermissionChecks || required == 0 {
+		return nil
+	}
+	if guildID.IsZero() {
+		if channelID.IsZero() {
+			return nil
+		}
+		channel, err := c.cache.GetChannel(channelID)
+		if err != nil || channel.GuildID.IsZero() {
+			return nil
+		}
+		guildID = channel.GuildID
+	}
+
+	permissions, err := c.cache.GetMemberPermissions(guildID, channelID, c.botID)
+	if err != nil {
+		return nil
+	}
+	if missing := required &^ permissions; missing != 0 {
+		return &ErrMissingPermissions{
+			Required: missing,
+			Channel:  channelID,
+			Guild:    guildID,
+		}
+	}
+	return nil
+}
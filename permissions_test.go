@@ -2,7 +2,12 @@ package disgord
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/andersfylling/disgord/json"
 )
@@ -70,3 +75,367 @@ func TestMember_GetPermissions(t *testing.T) {
 		t.Fatal("permissions should be 2048, is", p)
 	}
 }
+
+func TestPermissionViewChannel(t *testing.T) {
+	if PermissionViewChannel != 1<<10 {
+		t.Errorf("expected VIEW_CHANNEL to be 1 << 10, got %d", PermissionViewChannel)
+	}
+	if PermissionViewChannel == PermissionVoiceStream {
+		t.Error("expected VIEW_CHANNEL to differ from STREAM")
+	}
+}
+
+func TestComputePermissions(t *testing.T) {
+	const guildID, ownerID, userID Snowflake = 100, 1, 2
+	const modRole, mutedRole Snowflake = 200, 201
+
+	guild := &Guild{
+		ID:      guildID,
+		OwnerID: ownerID,
+		Roles: []*Role{
+			{ID: guildID, Permissions: PermissionViewChannel | PermissionSendMessages | PermissionEmbedLinks | PermissionSendMessagesInThreads},
+			{ID: modRole, Permissions: PermissionKickMembers},
+			{ID: mutedRole},
+		},
+	}
+	text := &Channel{
+		ID:   300,
+		Type: ChannelTypeGuildText,
+		PermissionOverwrites: []PermissionOverwrite{
+			{ID: userID, Type: PermissionOverwriteMember, Allow: PermissionSendMessages},
+			{ID: mutedRole, Type: PermissionOverwriteRole, Deny: PermissionSendMessages},
+			{ID: guildID, Type: PermissionOverwriteRole, Allow: PermissionAttachFiles},
+		},
+	}
+	hidden := &Channel{
+		ID:   301,
+		Type: ChannelTypeGuildText,
+		PermissionOverwrites: []PermissionOverwrite{
+			{ID: guildID, Type: PermissionOverwriteRole, Deny: PermissionViewChannel},
+			{ID: modRole, Type: PermissionOverwriteRole, Allow: PermissionViewChannel},
+		},
+	}
+	thread := &Channel{ID: 302, Type: ChannelTypeGuildPublicThread, ParentID: hidden.ID}
+	guild.Channels = []*Channel{text, hidden, thread}
+
+	now := time.Now()
+	member := func(roles ...Snowflake) *Member {
+		return &Member{GuildID: guildID, UserID: userID, Roles: roles}
+	}
+	timedOut := member()
+	timedOut.CommunicationDisabledUntil = Time{now.Add(time.Hour)}
+	admin := &Member{UserID: 3}
+	guild.Roles = append(guild.Roles, &Role{ID: 202, Permissions: PermissionAdministrator})
+	admin.Roles = []Snowflake{202}
+
+	testCases := []struct {
+		name     string
+		member   *Member
+		channel  *Channel
+		expected PermissionBit
+	}{
+		{"base", member(), nil, PermissionViewChannel | PermissionSendMessages | PermissionEmbedLinks | PermissionSendMessagesInThreads},
+		{"base with role", member(modRole), nil, PermissionViewChannel | PermissionSendMessages | PermissionEmbedLinks | PermissionSendMessagesInThreads | PermissionKickMembers},
+		{"owner", &Member{UserID: ownerID}, hidden, permissionsAll},
+		{"administrator", admin, hidden, permissionsAll},
+		{"everyone overwrite", member(), text, PermissionViewChannel | PermissionSendMessages | PermissionEmbedLinks | PermissionSendMessagesInThreads | PermissionAttachFiles},
+		{"member overwrite beats role overwrite", member(mutedRole), text, PermissionViewChannel | PermissionSendMessages | PermissionEmbedLinks | PermissionSendMessagesInThreads | PermissionAttachFiles},
+		{"hidden channel", member(), hidden, 0},
+		{"role overwrite beats everyone overwrite", member(modRole), hidden, PermissionViewChannel | PermissionSendMessages | PermissionEmbedLinks | PermissionSendMessagesInThreads | PermissionKickMembers},
+		{"thread uses parent overwrites", member(), thread, 0},
+		{"timeout", timedOut, text, PermissionViewChannel},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := computePermissions(guild, tc.member, tc.channel, now); got != tc.expected {
+				t.Errorf("expected %d, got %d", tc.expected, got)
+			}
+		})
+	}
+
+	t.Run("implicit send permissions", func(t *testing.T) {
+		muted := member(mutedRole)
+		text.PermissionOverwrites = text.PermissionOverwrites[1:]
+		defer func() {
+			text.PermissionOverwrites = append([]PermissionOverwrite{{ID: userID, Type: PermissionOverwriteMember, Allow: PermissionSendMessages}}, text.PermissionOverwrites...)
+		}()
+
+		got := computePermissions(guild, muted, text, now)
+		if got.Contains(PermissionSendMessages) || got.Contains(PermissionEmbedLinks) || got.Contains(PermissionAttachFiles) {
+			t.Errorf("expected send dependent permissions to be removed, got %d", got)
+		}
+	})
+}
+
+func TestBasicCache_GetMemberPermissions(t *testing.T) {
+	const guildID, userID, channelID Snowflake = 100, 2, 300
+	cache := NewBasicCache()
+	guild := &Guild{
+		ID:      guildID,
+		OwnerID: 1,
+		Roles:   []*Role{{ID: guildID, Permissions: PermissionViewChannel | PermissionSendMessages}},
+		Members: []*Member{{GuildID: guildID, UserID: userID, User: &User{ID: userID}}},
+		Channels: []*Channel{{
+			ID:      channelID,
+			GuildID: guildID,
+			PermissionOverwrites: []PermissionOverwrite{
+				{ID: guildID, Type: PermissionOverwriteRole, Deny: PermissionSendMessages},
+			},
+		}},
+	}
+	data, err := json.Marshal(guild)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cache.GuildCreate(data); err != nil {
+		t.Fatal(err)
+	}
+
+	permissions, err := cache.GetMemberPermissions(guildID, channelID, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if permissions != PermissionViewChannel {
+		t.Errorf("expected %d, got %d", PermissionViewChannel, permissions)
+	}
+
+	// updating the @everyone role must invalidate the computed permissions
+	update := []byte(`{"guild_id":"100","role":{"id":"100","permissions":"0"}}`)
+	if _, err = cache.GuildRoleUpdate(update); err != nil {
+		t.Fatal(err)
+	}
+	if permissions, err = cache.GetMemberPermissions(guildID, 0, userID); err != nil {
+		t.Fatal(err)
+	}
+	if permissions != 0 {
+		t.Errorf("expected no permissions after the role update, got %d", permissions)
+	}
+
+	if _, err = cache.GetMemberPermissions(guildID, channelID, 99); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("expected a cache miss for unknown members, got %v", err)
+	}
+}
+
+func TestClient_PreflightPermissionChecks(t *testing.T) {
+	const guildID, botID, channelID Snowflake = 100, 2, 300
+	cache := NewBasicCache()
+	guild := &Guild{
+		ID:      guildID,
+		OwnerID: 1,
+		Roles:   []*Role{{ID: guildID, Permissions: PermissionViewChannel | PermissionSendMessages | PermissionKickMembers}},
+		Members: []*Member{{GuildID: guildID, UserID: botID, User: &User{ID: botID}}},
+		Channels: []*Channel{{
+			ID:      channelID,
+			GuildID: guildID,
+			PermissionOverwrites: []PermissionOverwrite{
+				{ID: guildID, Type: PermissionOverwriteRole, Deny: PermissionSendMessages},
+			},
+		}},
+	}
+	data, err := json.Marshal(guild)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cache.GuildCreate(data); err != nil {
+		t.Fatal(err)
+	}
+
+	var requests int
+	client, err := NewClient(context.Background(), Config{
+		BotToken:                  "testing",
+		Cache:                     cache,
+		PreflightPermissionChecks: true,
+		HttpClient: doerMock(func(req *http.Request) (*http.Response, error) {
+			requests++
+			return jsonResponse(req, http.StatusNoContent, nil)
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.botID = botID
+
+	err = client.Guild(guildID).Member(5).Ban(&BanMember{})
+	var missing *ErrMissingPermissions
+	if !errors.As(err, &missing) {
+		t.Fatalf("expected missing permissions, got %v", err)
+	}
+	if missing.Required != PermissionBanMembers || missing.Guild != guildID || !missing.Channel.IsZero() {
+		t.Errorf("unexpected error content %+v", missing)
+	}
+
+	_, err = client.Channel(channelID).CreateMessage(&CreateMessage{Content: "hi"})
+	if !errors.As(err, &missing) {
+		t.Fatalf("expected missing permissions, got %v", err)
+	}
+	if missing.Required != PermissionSendMessages || missing.Guild != guildID || missing.Channel != channelID {
+		t.Errorf("unexpected error content %+v", missing)
+	}
+	if requests != 0 {
+		t.Fatalf("expected no requests to be sent, got %d", requests)
+	}
+
+	if err = client.Guild(guildID).Member(5).Kick(""); err != nil {
+		t.Fatal(err)
+	}
+	// permissions can not be computed for unknown guilds, so the request must be sent
+	if err = client.Guild(999).Member(5).Kick(""); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+}
+
+// messageCache adds messages to the BasicCache, which does not hold any
+type messageCache struct {
+	*BasicCache
+	messages map[Snowflake]*Message
+}
+
+func (c *messageCache) GetMessage(_, messageID Snowflake) (*Message, error) {
+	if msg, ok := c.messages[messageID]; ok {
+		return msg, nil
+	}
+	return nil, ErrCacheMiss
+}
+
+func TestClient_PreflightDeleteMessage(t *testing.T) {
+	const guildID, botID, userID, channelID Snowflake = 100, 2, 3, 300
+	cache := &messageCache{
+		BasicCache: NewBasicCache(),
+		messages: map[Snowflake]*Message{
+			10: {ID: 10, ChannelID: channelID, Author: &User{ID: botID}},
+			11: {ID: 11, ChannelID: channelID, Author: &User{ID: userID}},
+		},
+	}
+	guild := &Guild{
+		ID:       guildID,
+		OwnerID:  1,
+		Roles:    []*Role{{ID: guildID, Permissions: PermissionViewChannel | PermissionSendMessages}},
+		Members:  []*Member{{GuildID: guildID, UserID: botID, User: &User{ID: botID}}},
+		Channels: []*Channel{{ID: channelID, GuildID: guildID}},
+	}
+	data, err := json.Marshal(guild)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cache.GuildCreate(data); err != nil {
+		t.Fatal(err)
+	}
+
+	var deleted []Snowflake
+	client, err := NewClient(context.Background(), Config{
+		BotToken:                  "testing",
+		Cache:                     cache,
+		PreflightPermissionChecks: true,
+		HttpClient: doerMock(func(req *http.Request) (*http.Response, error) {
+			if req.Method != http.MethodDelete {
+				t.Errorf("expected the preflight check to not send requests, got %s %s", req.Method, req.URL.Path)
+			}
+			id, err := strconv.ParseUint(req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:], 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			deleted = append(deleted, Snowflake(id))
+			return jsonResponse(req, http.StatusNoContent, nil)
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.botID = botID
+
+	if err = client.Channel(channelID).Message(10).Delete(); err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.Channel(channelID).Message(11).Delete()
+	var missing *ErrMissingPermissions
+	if !errors.As(err, &missing) {
+		t.Fatalf("expected missing permissions, got %v", err)
+	}
+	if missing.Required != PermissionManageMessages {
+		t.Errorf("expected MANAGE_MESSAGES to be missing, got %d", missing.Required)
+	}
+	if msg := missing.Error(); msg != "missing permissions MANAGE_MESSAGES in channel 300 in guild 100" {
+		t.Errorf("unexpected error message %q", msg)
+	}
+
+	// the author of an uncached message is unknown, so it is not checked
+	if err = client.Channel(channelID).Message(12).Delete(); err != nil {
+		t.Fatal(err)
+	}
+	if len(deleted) != 2 || deleted[0] != 10 || deleted[1] != 12 {
+		t.Errorf("expected the bot message and the uncached message to be deleted, got %v", deleted)
+	}
+}
+
+func TestGuild_RoleHierarchy(t *testing.T) {
+	const guildID Snowflake = 100
+	const owner, moderator, member, admin Snowflake = 1, 2, 3, 4
+	const modRole, memberRole, adminRole, botRole Snowflake = 10, 11, 12, 13
+	guild := &Guild{
+		ID:      guildID,
+		OwnerID: owner,
+		Roles: []*Role{
+			{ID: guildID, Position: 0, Permissions: PermissionViewChannel},
+			{ID: memberRole, Position: 1},
+			{ID: modRole, Position: 2, Permissions: PermissionKickMembers | PermissionManageRoles | PermissionManageChannels},
+			{ID: adminRole, Position: 2, Permissions: PermissionAdministrator},
+			{ID: botRole, Position: 1, Managed: true},
+		},
+		Members: []*Member{
+			{UserID: owner},
+			{UserID: moderator, Roles: []Snowflake{modRole, memberRole}},
+			{UserID: member, Roles: []Snowflake{memberRole}},
+			{UserID: admin, Roles: []Snowflake{adminRole}},
+		},
+		Channels: []*Channel{{
+			ID: 300,
+			PermissionOverwrites: []PermissionOverwrite{
+				{ID: modRole, Type: PermissionOverwriteRole, Deny: PermissionManageChannels},
+			},
+		}, {ID: 301}},
+	}
+
+	if role := guild.HighestRole(guild.Members[1]); role.ID != modRole {
+		t.Errorf("expected the moderator role to be the highest, got %d", role.ID)
+	}
+	if role := guild.HighestRole(guild.Members[0]); role.ID != guildID {
+		t.Errorf("expected @everyone to be the highest role of a member without roles, got %d", role.ID)
+	}
+
+	var missing *ErrMissingPermissions
+	testCases := []struct {
+		name string
+		err  error
+		is   func(error) bool
+	}{
+		{"moderator kicks member", guild.CanManageMember(moderator, member, PermissionKickMembers), nil},
+		{"member kicks moderator", guild.CanManageMember(member, moderator, PermissionKickMembers), func(err error) bool { return errors.As(err, &missing) }},
+		// equal positions are ordered by ID, which places the moderator role above the admin role
+		{"moderator kicks admin", guild.CanManageMember(moderator, admin, PermissionKickMembers), nil},
+		{"admin kicks moderator", guild.CanManageMember(admin, moderator, PermissionKickMembers), func(err error) bool { return errors.Is(err, ErrRoleHierarchy) }},
+		{"admin kicks owner", guild.CanManageMember(admin, owner, PermissionKickMembers), func(err error) bool { return errors.Is(err, ErrRoleHierarchy) }},
+		{"owner kicks moderator", guild.CanManageMember(owner, moderator, PermissionKickMembers), nil},
+		{"moderator assigns member role", guild.CanAssignRole(moderator, memberRole), nil},
+		{"moderator assigns own role", guild.CanAssignRole(moderator, modRole), func(err error) bool { return errors.Is(err, ErrRoleHierarchy) }},
+		{"owner assigns managed role", guild.CanAssignRole(owner, botRole), func(err error) bool { return errors.Is(err, ErrIllegalValue) }},
+		{"member assigns member role", guild.CanAssignRole(member, memberRole), func(err error) bool { return errors.As(err, &missing) }},
+		{"moderator edits denied channel", guild.CanEditChannel(moderator, 300), func(err error) bool { return errors.As(err, &missing) }},
+		{"moderator edits channel", guild.CanEditChannel(moderator, 301), nil},
+		{"admin edits denied channel", guild.CanEditChannel(admin, 300), nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.is == nil && tc.err != nil {
+				t.Errorf("expected no error, got %v", tc.err)
+			}
+			if tc.is != nil && !tc.is(tc.err) {
+				t.Errorf("unexpected error %v", tc.err)
+			}
+		})
+	}
+}
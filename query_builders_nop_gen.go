@@ -127,6 +127,18 @@ func (c *channelQueryBuilderNop) GetWebhooks() ([]*Webhook, error) {
 	return nil, nil
 }
 
+func (c *channelQueryBuilderNop) IterateJoinedPrivateArchivedThreads(_ *IterateArchivedThreads) *ThreadIterator {
+	return nil
+}
+
+func (c *channelQueryBuilderNop) IteratePrivateArchivedThreads(_ *IterateArchivedThreads) *ThreadIterator {
+	return nil
+}
+
+func (c *channelQueryBuilderNop) IteratePublicArchivedThreads(_ *IterateArchivedThreads) *ThreadIterator {
+	return nil
+}
+
 func (c *channelQueryBuilderNop) JoinThread() error {
 	return nil
 }
@@ -143,6 +155,10 @@ func (c *channelQueryBuilderNop) Message(_ Snowflake) MessageQueryBuilder {
 	return nil
 }
 
+func (c *channelQueryBuilderNop) PurgeMessages(_ *PurgeMessages) (*PurgeResult, error) {
+	return nil, nil
+}
+
 func (c *channelQueryBuilderNop) RemoveThreadMember(_ Snowflake) error {
 	return nil
 }
@@ -259,6 +275,10 @@ func (c *currentUserQueryBuilderNop) GetGuilds(_ *GetCurrentUserGuilds) ([]*Guil
 	return nil, nil
 }
 
+func (c *currentUserQueryBuilderNop) IterateGuilds(_ *IterateCurrentUserGuilds) *GuildIterator {
+	return nil
+}
+
 func (c *currentUserQueryBuilderNop) Update(_ *UpdateUser) (*User, error) {
 	return nil, nil
 }
@@ -342,6 +362,14 @@ func (g *gatewayQueryBuilderNop) GetBot() (*gateway.GatewayBot, error) {
 	return nil, nil
 }
 
+func (g *gatewayQueryBuilderNop) GuildAuditLogEntryCreate(_ func(Session, *GuildAuditLogEntryCreate), _ ...func(Session, *GuildAuditLogEntryCreate)) {
+	return
+}
+
+func (g *gatewayQueryBuilderNop) GuildAuditLogEntryCreateChan(_ chan *GuildAuditLogEntryCreate, _ ...chan *GuildAuditLogEntryCreate) {
+	return
+}
+
 func (g *gatewayQueryBuilderNop) GuildBanAdd(_ func(Session, *GuildBanAdd), _ ...func(Session, *GuildBanAdd)) {
 	return
 }
@@ -922,6 +950,14 @@ func (g *guildQueryBuilderNop) GetWidget() (*GuildWidget, error) {
 	return nil, nil
 }
 
+func (g *guildQueryBuilderNop) IterateAuditLogs(_ *IterateAuditLogs) *AuditLogIterator {
+	return nil
+}
+
+func (g *guildQueryBuilderNop) IterateBans(_ *IterateBans) *BanIterator {
+	return nil
+}
+
 func (g *guildQueryBuilderNop) Leave() error {
 	return nil
 }
@@ -1042,6 +1078,10 @@ func (g *guildScheduledEventQueryBuilderNop) GetMembers(_ *GetScheduledEventMemb
 	return nil, nil
 }
 
+func (g *guildScheduledEventQueryBuilderNop) IterateMembers(_ *IterateScheduledEventMembers) *ScheduledEventMemberIterator {
+	return nil
+}
+
 func (g *guildScheduledEventQueryBuilderNop) Update(_ *UpdateScheduledEvent) (*GuildScheduledEvent, error) {
 	return nil, nil
 }
@@ -1166,6 +1206,10 @@ func (r *reactionQueryBuilderNop) Get(_ URLQueryStringer) ([]*User, error) {
 	return nil, nil
 }
 
+func (r *reactionQueryBuilderNop) Iterate(_ *IterateReactions) *UserIterator {
+	return nil
+}
+
 type userQueryBuilderNop struct {
 	Ctx       context.Context
 	Flags     Flag
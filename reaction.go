@@ -60,6 +60,9 @@ type ReactionQueryBuilder interface {
 	// Get Get a list of Users that reacted with this emoji. Returns an array of user objects on success.
 	Get(params URLQueryStringer) (reactors []*User, err error)
 
+	// Iterate walks the Users that reacted with this emoji, ordered by user ID. Only PaginateForward is supported.
+	Iterate(params *IterateReactions) *UserIterator
+
 	// DeleteOwn Delete a reaction the current user has made for the message.
 	// Returns a 204 empty response on success.
 	DeleteOwn() (err error)
@@ -189,6 +192,12 @@ func (r reactionQueryBuilder) DeleteUser(userID Snowflake) error {
 		return err
 	}
 
+	if userID != r.client.botID {
+		if err = r.client.preflight(0, r.cid, PermissionManageMessages); err != nil {
+			return err
+		}
+	}
+
 	req := r.client.newRESTRequest(&httd.Request{
 		Method:   http.MethodDelete,
 		Endpoint: endpoint.ChannelMessageReactionUser(r.cid, r.mid, emojiCode, userID),
@@ -247,3 +256,57 @@ func (r reactionQueryBuilder) Get(params URLQueryStringer) (ret []*User, err err
 
 	return getUsers(req.Execute)
 }
+
+// IterateReactions configures a UserIterator for reactions.
+type IterateReactions struct {
+	Pagination
+
+	// Until stops the iteration when it returns true. The user is not returned.
+	Until func(user *User) bool
+}
+
+// UserIterator walks a list of users. See ReactionQueryBuilder.Iterate.
+type UserIterator struct {
+	it *cursorIterator
+}
+
+// Next returns the next user, or ErrIteratorDone after the last user.
+func (u *UserIterator) Next(ctx context.Context) (*User, error) {
+	item, err := u.it.next(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return item.(*User), nil
+}
+
+// Iterate creates a iterator for the Users that reacted with this emoji.
+func (r reactionQueryBuilder) Iterate(params *IterateReactions) *UserIterator {
+	const pageSizeMax = 100
+	if params == nil {
+		params = &IterateReactions{}
+	}
+
+	fetch := func(ctx context.Context, _, after Snowflake, limit int) ([]interface{}, error) {
+		users, err := r.WithContext(ctx).Get(&GetReactionURL{After: after, Limit: limit})
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, len(users))
+		for i := range users {
+			items[i] = users[i]
+		}
+		return items, nil
+	}
+	id := func(item interface{}) Snowflake {
+		return item.(*User).ID
+	}
+
+	it := newCursorIterator(params.Pagination, PaginateForward, pageSizeMax, fetch, id)
+	if params.Until != nil {
+		until := params.Until
+		it.until = func(item interface{}) bool {
+			return until(item.(*User))
+		}
+	}
+	return &UserIterator{it: it.only(PaginateForward)}
+}
@@ -66,6 +66,9 @@ func (c *Client) demultiplexer(d *dispatcher, read <-chan *gateway.Event) {
 		}
 		resource := resourceI.(evtResource)
 		resource.setShardID(evt.ShardID)
+		if c.restCache != nil {
+			c.restCache.invalidateEvent(resource)
+		}
 
 		go d.dispatch(evt.Name, resource)
 	}
@@ -20,6 +20,8 @@ func defineResource(evt string) (resource evtResource) {
 		resource = &ChannelPinsUpdate{}
 	case EvtChannelUpdate:
 		resource = &ChannelUpdate{}
+	case EvtGuildAuditLogEntryCreate:
+		resource = &GuildAuditLogEntryCreate{}
 	case EvtGuildBanAdd:
 		resource = &GuildBanAdd{}
 	case EvtGuildBanRemove:
@@ -139,6 +141,10 @@ func isHandler(h Handler) (ok bool) {
 		ok = true
 	case chan *ChannelUpdate:
 		ok = true
+	case HandlerGuildAuditLogEntryCreate:
+		ok = true
+	case chan *GuildAuditLogEntryCreate:
+		ok = true
 	case HandlerGuildBanAdd:
 		ok = true
 	case chan *GuildBanAdd:
@@ -335,6 +341,8 @@ func closeChannel(channel interface{}) {
 		close(t)
 	case chan *ChannelUpdate:
 		close(t)
+	case chan *GuildAuditLogEntryCreate:
+		close(t)
 	case chan *GuildBanAdd:
 		close(t)
 	case chan *GuildBanRemove:
@@ -479,6 +487,12 @@ func (d *dispatcher) trigger(h Handler, evt resource) {
 		t <- evt.(*ChannelUpdate)
 	case chan<- *ChannelUpdate:
 		t <- evt.(*ChannelUpdate)
+	case HandlerGuildAuditLogEntryCreate:
+		t(d.session, evt.(*GuildAuditLogEntryCreate))
+	case chan *GuildAuditLogEntryCreate:
+		t <- evt.(*GuildAuditLogEntryCreate)
+	case chan<- *GuildAuditLogEntryCreate:
+		t <- evt.(*GuildAuditLogEntryCreate)
 	case HandlerGuildBanAdd:
 		t(d.session, evt.(*GuildBanAdd))
 	case chan *GuildBanAdd:
@@ -774,6 +788,9 @@ type HandlerChannelPinsUpdate = func(s Session, h *ChannelPinsUpdate)
 // HandlerChannelUpdate is triggered by ChannelUpdate events
 type HandlerChannelUpdate = func(s Session, h *ChannelUpdate)
 
+// HandlerGuildAuditLogEntryCreate is triggered by GuildAuditLogEntryCreate events
+type HandlerGuildAuditLogEntryCreate = func(s Session, h *GuildAuditLogEntryCreate)
+
 // HandlerGuildBanAdd is triggered by GuildBanAdd events
 type HandlerGuildBanAdd = func(s Session, h *GuildBanAdd)
 
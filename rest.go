@@ -20,6 +20,74 @@ import (
 
 type ErrRest = httd.ErrREST
 
+// ErrRESTCircuitOpen is returned, without sending the request, while the circuit breaker of
+// Config.RESTInvalidRequestPolicy is open.
+type ErrRESTCircuitOpen = httd.ErrCircuitOpen
+
+// ErrCloudflareBan is returned when Cloudflare answers instead of Discord, as the IP address has been
+// banned for sending too many invalid requests.
+type ErrCloudflareBan = httd.ErrCloudflareBan
+
+// RESTInvalidRequestPolicy decides what happens as invalid requests are counted, see Config.RESTInvalidRequestPolicy.
+type RESTInvalidRequestPolicy = httd.InvalidRequestPolicy
+
+// RESTRetryPolicy decides which failed REST requests are sent again. Retries go through the rate limit
+// buckets like any other request.
+type RESTRetryPolicy = httd.RetryPolicy
+
+// DefaultRESTRetryPolicy retries GET, PUT and DELETE requests up to three times on 502, 503 and 504
+// responses, and on network errors such as timeouts and connection resets.
+func DefaultRESTRetryPolicy() *RESTRetryPolicy {
+	return httd.DefaultRetryPolicy()
+}
+
+// RESTInterceptor wraps every REST request sent to Discord, once per attempt, with access to the request,
+// the rate limit bucket, the response and the timing. Interceptors must call next to send the request.
+type RESTInterceptor = httd.Interceptor
+
+// RESTInterceptorFunc allows a function to be used as a RESTInterceptor.
+type RESTInterceptorFunc = httd.InterceptorFunc
+
+// RESTExchange is a single attempt at sending a REST request, see RESTInterceptor.
+type RESTExchange = httd.Exchange
+
+// RESTRoundTrip sends a REST exchange to the next interceptor, or to Discord.
+type RESTRoundTrip = httd.RoundTrip
+
+// RESTBucketManager keeps track of the REST rate limits, see Config.RESTBucketManager.
+type RESTBucketManager = httd.RESTBucketManager
+
+// RESTBucketInfo describes the state of a REST rate limit bucket, see Client.RESTRatelimitSnapshot.
+type RESTBucketInfo = httd.BucketInfo
+
+// RESTBucketsSnapshot describes the state of every REST rate limit bucket, see Client.RESTRatelimitSnapshot.
+type RESTBucketsSnapshot = httd.BucketsSnapshot
+
+// RESTBucketStore holds the rate limit state of the bucket manager created by NewRESTBucketStoreManager.
+// Implement it to share rate limits through eg. a database.
+type RESTBucketStore = httd.BucketStore
+
+// RESTBucketState is the rate limit state of a bucket, as given by Discord.
+type RESTBucketState = httd.BucketState
+
+// NewRESTBucketStoreManager creates a RESTBucketManager that keeps its state in the given store. Clients
+// using the same store share the rate limits, and which endpoints share a bucket, such that several
+// processes can use the same bot token without exceeding the rate limits together.
+func NewRESTBucketStoreManager(store RESTBucketStore) RESTBucketManager {
+	return httd.NewStoreManager(store)
+}
+
+// NewMemoryRESTBucketStore creates a RESTBucketStore that can be shared by clients within a process.
+func NewMemoryRESTBucketStore() RESTBucketStore {
+	return httd.NewMemoryBucketStore()
+}
+
+// NewFileRESTBucketStore creates a RESTBucketStore that keeps the state in a file, to be shared by
+// processes on the same host. Every operation holds an OS file lock on a lock file next to it.
+func NewFileRESTBucketStore(path string) RESTBucketStore {
+	return httd.NewFileBucketStore(path)
+}
+
 // URLQueryStringer converts a struct of values to a valid URL query string
 type URLQueryStringer interface {
 	URLQueryString() string
@@ -137,11 +205,48 @@ func (r *rest) processContent(body []byte) (v interface{}, err error) {
 	return obj, nil
 }
 
+// decode creates the object of a response body, as sent by Discord.
+func (r *rest) decode(body []byte) (v interface{}, err error) {
+	if v, err = r.processContent(body); err != nil {
+		return nil, err
+	}
+	if r.flags.Sort() {
+		Sort(v, r.flags)
+	}
+	return v, nil
+}
+
 func (r *rest) Execute() (v interface{}, err error) {
+	if r.httpMethod == http.MethodGet {
+		if r.c.restCache != nil && !r.flags.Ignorecache() {
+			if body, ok := r.c.restCache.get(r.conf.Endpoint); ok {
+				return r.decode(body)
+			}
+		}
+		if r.c.coalescer != nil {
+			return r.c.coalescer.execute(r)
+		}
+	}
+
+	v, _, err = r.execute()
+	return v, err
+}
+
+// execute sends the request and decodes the response, which is also returned as is.
+func (r *rest) execute() (v interface{}, body []byte, err error) {
+	var generation uint64
+	if r.c.restCache != nil && r.httpMethod == http.MethodGet {
+		generation = r.c.restCache.generation(r.conf.Endpoint)
+	}
+
 	var resp *http.Response
-	var body []byte
-	if resp, body, err = r.doRequest(); err != nil {
-		return nil, err
+	resp, body, err = r.doRequest()
+	if r.c.restCache != nil && r.httpMethod != http.MethodGet {
+		// the request may have changed the resource even when it failed, such as on a timeout
+		r.c.restCache.invalidateRequest(r.httpMethod, r.conf.Endpoint)
+	}
+	if err != nil {
+		return nil, nil, err
 	}
 
 	successful := func(code int) bool {
@@ -153,19 +258,18 @@ func (r *rest) Execute() (v interface{}, err error) {
 			HTTPCode: resp.StatusCode,
 			Msg:      "unexpected http response code. Got " + resp.Status,
 		}
-		return nil, err
+		return nil, nil, err
 	}
 
 	var obj interface{}
-	if obj, err = r.processContent(body); err != nil {
-		return nil, err
+	if obj, err = r.decode(body); err != nil {
+		return nil, nil, err
 	}
 
-	if r.flags.Sort() {
-		Sort(obj, r.flags)
+	if r.c.restCache != nil && r.httpMethod == http.MethodGet {
+		r.c.restCache.store(r.conf.Endpoint, body, generation)
 	}
-
-	return obj, nil
+	return obj, body, nil
 }
 
 type fRESTRequestMiddleware func(resp *http.Response, body []byte, err error) error
@@ -0,0 +1,211 @@
+package disgord
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andersfylling/disgord/internal/endpoint"
+)
+
+// DefaultRESTCacheTTLs returns the time to live of REST responses for the resources that the Cache does not hold.
+// See Config.RESTCacheTTLs.
+func DefaultRESTCacheTTLs() map[string]time.Duration {
+	return map[string]time.Duration{
+		"webhooks":     time.Minute,
+		"invites":      time.Minute,
+		"bans":         time.Minute,
+		"integrations": time.Minute,
+		"pins":         time.Minute,
+		"regions":      time.Hour,
+	}
+}
+
+// restCacheSweepInterval is how often expired responses are removed from the REST cache
+const restCacheSweepInterval = time.Minute
+
+// restCache is a read-through cache of REST responses, see Config.RESTCacheTTLs.
+type restCache struct {
+	mu        sync.Mutex
+	ttls      map[string]time.Duration
+	entries   map[string]*restCacheEntry
+	lastSweep time.Time
+
+	// generations count the invalidations of every resource, and of all resources at once. A response that was
+	// requested before an invalidation may be stale, so it is not stored.
+	generations   map[string]uint64
+	allGeneration uint64
+}
+
+type restCacheEntry struct {
+	resource string
+	path     string
+	body     []byte
+	expires  time.Time
+}
+
+func newRESTCache(ttls map[string]time.Duration) *restCache {
+	c := &restCache{
+		ttls:      make(map[string]time.Duration, len(ttls)),
+		entries:   make(map[string]*restCacheEntry),
+		lastSweep: time.Now(),
+
+		generations: make(map[string]uint64),
+	}
+	for resource, ttl := range ttls {
+		if ttl > 0 {
+			c.ttls[resource] = ttl
+		}
+	}
+	return c
+}
+
+// endpointPath removes the query string of an endpoint.
+func endpointPath(e string) string {
+	if i := strings.IndexByte(e, '?'); i >= 0 {
+		return e[:i]
+	}
+	return e
+}
+
+// resource returns the last segment of the endpoint path that has a time to live, or an empty string.
+func (c *restCache) resource(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if _, ok := c.ttls[segments[i]]; ok {
+			return segments[i]
+		}
+	}
+	return ""
+}
+
+// get returns the cached response body of a GET request.
+func (c *restCache) get(e string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[e]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expires) {
+		delete(c.entries, e)
+		return nil, false
+	}
+	return entry.body, true
+}
+
+// generation returns the invalidation generation of the endpoint resource, which must be captured before the
+// request is sent and given to store.
+func (c *restCache) generation(e string) uint64 {
+	resource := c.resource(endpointPath(e))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.allGeneration + c.generations[resource]
+}
+
+// store caches the response body of a GET request, when the endpoint has a time to live and the resource was not
+// invalidated since the generation was captured.
+func (c *restCache) store(e string, body []byte, generation uint64) {
+	path := endpointPath(e)
+	resource := c.resource(path)
+	if resource == "" {
+		return
+	}
+
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.allGeneration+c.generations[resource] != generation {
+		return
+	}
+
+	if now.Sub(c.lastSweep) > restCacheSweepInterval {
+		for key, entry := range c.entries {
+			if now.After(entry.expires) {
+				delete(c.entries, key)
+			}
+		}
+		c.lastSweep = now
+	}
+
+	c.entries[e] = &restCacheEntry{
+		resource: resource,
+		path:     path,
+		body:     body,
+		expires:  now.Add(c.ttls[resource]),
+	}
+}
+
+// invalidate removes the cached responses of the resource whose endpoint path is within one of the given paths.
+// An empty resource matches any resource, and no paths matches any path.
+func (c *restCache) invalidate(resource string, paths ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if resource == "" {
+		c.allGeneration++
+	} else {
+		c.generations[resource]++
+	}
+
+	for key, entry := range c.entries {
+		if resource != "" && entry.resource != resource {
+			continue
+		}
+		within := len(paths) == 0
+		for _, path := range paths {
+			if entry.path == path || strings.HasPrefix(entry.path, path+"/") {
+				within = true
+				break
+			}
+		}
+		if within {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// invalidateRequest removes the cached responses a mutating request may have changed. Every cached response of
+// the same resource is removed, as the resource can often be changed through several endpoints, such as a
+// webhook that is deleted through /webhooks/{webhook.id} but listed by /channels/{channel.id}/webhooks.
+func (c *restCache) invalidateRequest(method, e string) {
+	path := endpointPath(e)
+	if resource := c.resource(path); resource != "" {
+		c.invalidate(resource)
+	}
+	if method == http.MethodDelete {
+		c.invalidate("", path)
+	}
+}
+
+// invalidateEvent removes the cached responses that a gateway event tells have changed.
+func (c *restCache) invalidateEvent(evt interface{}) {
+	switch e := evt.(type) {
+	case *WebhooksUpdate:
+		c.invalidate("webhooks", endpoint.Channel(e.ChannelID), endpoint.Guild(e.GuildID))
+	case *InviteCreate:
+		c.invalidate("invites", endpoint.Channel(e.ChannelID), endpoint.Guild(e.GuildID), endpoint.Invite(e.Code))
+	case *InviteDelete:
+		c.invalidate("invites", endpoint.Channel(e.ChannelID), endpoint.Guild(e.GuildID), endpoint.Invite(e.Code))
+	case *GuildBanAdd:
+		c.invalidate("bans", endpoint.Guild(e.GuildID))
+	case *GuildBanRemove:
+		c.invalidate("bans", endpoint.Guild(e.GuildID))
+	case *GuildIntegrationsUpdate:
+		c.invalidate("integrations", endpoint.Guild(e.GuildID))
+	case *ChannelPinsUpdate:
+		c.invalidate("pins", endpoint.Channel(e.ChannelID))
+	case *ChannelDelete:
+		if e.Channel != nil {
+			c.invalidate("", endpoint.Channel(e.Channel.ID))
+		}
+	case *GuildDelete:
+		if e.UnavailableGuild != nil {
+			c.invalidate("", endpoint.Guild(e.UnavailableGuild.ID))
+		}
+	}
+}
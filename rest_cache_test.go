@@ -0,0 +1,112 @@
+//go:build !integration
+// +build !integration
+
+package disgord
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_RESTCacheTTLs(t *testing.T) {
+	var requests int
+	client, err := NewClient(context.Background(), Config{
+		BotToken:      "testing",
+		DisableCache:  true,
+		RESTCacheTTLs: DefaultRESTCacheTTLs(),
+		HttpClient: doerMock(func(req *http.Request) (*http.Response, error) {
+			requests++
+			if req.Method == http.MethodDelete {
+				return jsonResponse(req, http.StatusNoContent, nil)
+			}
+			return jsonResponse(req, http.StatusOK, []*Webhook{{ID: 5, Name: "hook"}})
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	getWebhooks := func(expectedRequests int, flags ...Flag) {
+		t.Helper()
+		webhooks, err := client.Channel(1).WithFlags(flags...).GetWebhooks()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(webhooks) != 1 || webhooks[0].Name != "hook" {
+			t.Fatalf("unexpected webhooks %+v", webhooks)
+		}
+		webhooks[0].Name = "changed by the caller"
+		if requests != expectedRequests {
+			t.Fatalf("expected %d requests, got %d", expectedRequests, requests)
+		}
+	}
+
+	getWebhooks(1)
+	getWebhooks(1)
+	getWebhooks(2, IgnoreCache)
+	getWebhooks(2)
+
+	// a mutating request touching the resource
+	if err = client.Webhook(5).Delete(); err != nil {
+		t.Fatal(err)
+	}
+	getWebhooks(4)
+	getWebhooks(4)
+
+	// a gateway event touching the resource
+	client.restCache.invalidateEvent(&WebhooksUpdate{GuildID: 2, ChannelID: 3})
+	getWebhooks(4)
+	client.restCache.invalidateEvent(&WebhooksUpdate{GuildID: 2, ChannelID: 1})
+	getWebhooks(5)
+
+	// responses expire
+	client.restCache.mu.Lock()
+	for _, entry := range client.restCache.entries {
+		entry.expires = time.Now().Add(-time.Second)
+	}
+	client.restCache.mu.Unlock()
+	getWebhooks(6)
+
+	// endpoints without a time to live are not cached
+	if _, err = client.Guild(1).GetEmojis(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.Guild(1).GetEmojis(); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 8 {
+		t.Errorf("expected uncached requests, got %d requests", requests)
+	}
+}
+
+func TestClient_RESTCacheInvalidatedWhileRequesting(t *testing.T) {
+	var client *Client
+	var requests int
+	client, err := NewClient(context.Background(), Config{
+		BotToken:      "testing",
+		DisableCache:  true,
+		RESTCacheTTLs: DefaultRESTCacheTTLs(),
+		HttpClient: doerMock(func(req *http.Request) (*http.Response, error) {
+			requests++
+			if requests == 1 {
+				// the webhooks change while the first response is on its way
+				client.restCache.invalidateEvent(&WebhooksUpdate{GuildID: 2, ChannelID: 1})
+			}
+			return jsonResponse(req, http.StatusOK, []*Webhook{{ID: 5, Name: "hook"}})
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err = client.Channel(1).GetWebhooks(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("expected the stale response to not be cached, got %d requests", requests)
+	}
+}
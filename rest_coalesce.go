@@ -0,0 +1,133 @@
+package disgord
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// restCoalescer lets concurrent identical GET requests share one REST call, see Config.CoalesceRESTRequests.
+type restCoalescer struct {
+	mu    sync.Mutex
+	calls map[string]*coalescedCall
+}
+
+type coalescedCall struct {
+	done chan struct{}
+
+	// followers is the number of callers waiting for the result, see restCoalescer.followers
+	followers int
+
+	// v is never handed out, as callers may change or pool their result; each caller gets a copy
+	v    interface{}
+	body []byte
+	err  error
+
+	// cancelled is true when the request failed as the context of the caller that sent it was done
+	cancelled bool
+}
+
+func newRESTCoalescer() *restCoalescer {
+	return &restCoalescer{calls: make(map[string]*coalescedCall)}
+}
+
+// execute sends the request, unless an identical request is already in flight, in which case its result is
+// copied once it arrives. Every caller gets its own copy of the result.
+func (g *restCoalescer) execute(r *rest) (v interface{}, err error) {
+	key := coalesceKey(r)
+
+	g.mu.Lock()
+	if call, ok := g.calls[key]; ok {
+		call.followers++
+		g.mu.Unlock()
+		select {
+		case <-call.done:
+		case <-r.conf.Ctx.Done():
+			return nil, r.conf.Ctx.Err()
+		}
+		if call.cancelled {
+			v, _, err = r.execute()
+			return v, err
+		}
+		if call.err != nil {
+			return nil, call.err
+		}
+		return r.copyResult(call.v, call.body)
+	}
+	call := &coalescedCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	call.v, call.body, call.err = r.execute()
+	call.cancelled = call.err != nil && r.conf.Ctx.Err() != nil
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	close(call.done)
+
+	if call.err != nil {
+		return nil, call.err
+	}
+	return r.copyResult(call.v, call.body)
+}
+
+// followers returns how many callers wait for an in-flight request to the endpoint, or -1 when no request to
+// the endpoint is in flight.
+func (g *restCoalescer) followers(endpoint string) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	followers := -1
+	for key, call := range g.calls {
+		if strings.HasPrefix(key, endpoint+"|") {
+			followers = call.followers
+		}
+	}
+	return followers
+}
+
+func coalesceKey(r *rest) string {
+	return r.conf.Endpoint + "|" + strconv.FormatUint(uint64(r.flags), 10)
+}
+
+// copyResult returns a deep copy of a shared result, or decodes the response body again when the result
+// can not be copied.
+func (r *rest) copyResult(v interface{}, body []byte) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if cp, ok := v.(DeepCopier); ok {
+		return cp.deepCopy(), nil
+	}
+	if cp, ok := deepCopySlice(v); ok {
+		return cp, nil
+	}
+	return r.decode(body)
+}
+
+// deepCopySlice copies a pointer to a slice of DeepCopier's, such as *[]*Member.
+func deepCopySlice(v interface{}) (interface{}, bool) {
+	ptr := reflect.ValueOf(v)
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() || ptr.Elem().Kind() != reflect.Slice {
+		return nil, false
+	}
+	src := ptr.Elem()
+	if !src.Type().Elem().Implements(reflect.TypeOf((*DeepCopier)(nil)).Elem()) {
+		return nil, false
+	}
+
+	dst := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+	for i := 0; i < src.Len(); i++ {
+		item := src.Index(i)
+		if item.Kind() == reflect.Ptr && item.IsNil() {
+			continue
+		}
+		dst.Index(i).Set(reflect.ValueOf(item.Interface().(DeepCopier).deepCopy()))
+	}
+
+	cp := reflect.New(src.Type())
+	cp.Elem().Set(dst)
+	return cp.Interface(), true
+}
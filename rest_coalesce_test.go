@@ -0,0 +1,187 @@
+//go:build !integration
+// +build !integration
+
+package disgord
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_CoalesceRESTRequests(t *testing.T) {
+	var requests int32
+	release := make(chan struct{})
+	client, err := NewClient(context.Background(), Config{
+		BotToken:             "testing",
+		DisableCache:         true,
+		CoalesceRESTRequests: true,
+		HttpClient: doerMock(func(req *http.Request) (*http.Response, error) {
+			atomic.AddInt32(&requests, 1)
+			<-release
+			if req.URL.Path == "/api/v9/guilds/1/roles" {
+				return jsonResponse(req, http.StatusOK, []*Role{{ID: 2, Name: "mod"}, {ID: 3, Name: "admin"}})
+			}
+			return jsonResponse(req, http.StatusOK, &User{ID: 1, Username: "test"})
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const callers = 10
+	users := make([]*User, callers)
+	roles := make([][]*Role, callers)
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			var err error
+			if users[i], err = client.User(1).Get(); err != nil {
+				t.Error(err)
+			}
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			var err error
+			if roles[i], err = client.Guild(1).GetRoles(); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	waitForFollowers(t, client, callers-1, "/users/1", "/guilds/1/roles")
+	close(release)
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("expected one request per endpoint, got %d", n)
+	}
+	for i := 1; i < callers; i++ {
+		if users[i] == users[0] || users[i].Username != "test" {
+			t.Errorf("expected every caller to get its own copy of the user, got %+v", users[i])
+		}
+		if len(roles[i]) != 2 || roles[i][0] == roles[0][0] || roles[i][1].Name != "admin" {
+			t.Errorf("expected every caller to get its own copy of the roles, got %+v", roles[i])
+		}
+	}
+
+	// requests are only shared while in flight
+	if _, err = client.User(1).Get(); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 3 {
+		t.Errorf("expected a new request, got %d requests", n)
+	}
+}
+
+// waitForFollowers blocks until every endpoint has a request in flight with the given number of callers waiting
+// for it.
+func waitForFollowers(t *testing.T, client *Client, followers int, endpoints ...string) {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		joined := 0
+		for _, endpoint := range endpoints {
+			if client.coalescer.followers(endpoint) == followers {
+				joined++
+			}
+		}
+		if joined == len(endpoints) {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d callers to wait for %v", followers, endpoints)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestClient_CoalesceRESTRequests_LeaderMutatesResult(t *testing.T) {
+	release := make(chan struct{})
+	client, err := NewClient(context.Background(), Config{
+		BotToken:             "testing",
+		DisableCache:         true,
+		CoalesceRESTRequests: true,
+		HttpClient: doerMock(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/api/v9/users/1" {
+				<-release
+			}
+			return jsonResponse(req, http.StatusOK, &User{ID: 1, Username: "test"})
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	leader := make(chan *User)
+	go func() {
+		user, err := client.User(1).Get()
+		if err != nil {
+			t.Error(err)
+		}
+		// the caller owns its result
+		user.Username = "mutated"
+		leader <- user
+	}()
+	waitForFollowers(t, client, 0, "/users/1")
+
+	const followers = 5
+	users := make([]*User, followers)
+	var wg sync.WaitGroup
+	for i := 0; i < followers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var err error
+			if users[i], err = client.User(1).Get(); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	waitForFollowers(t, client, followers, "/users/1")
+	close(release)
+	wg.Wait()
+
+	if user := <-leader; user.Username != "mutated" {
+		t.Errorf("expected the leader to keep its change, got %q", user.Username)
+	}
+	for _, user := range users {
+		if user == nil || user.Username != "test" {
+			t.Errorf("expected the change of the leader to not leak into the copies, got %+v", user)
+		}
+	}
+}
+
+func TestClient_CoalesceRESTRequests_FollowerContext(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	client, err := NewClient(context.Background(), Config{
+		BotToken:             "testing",
+		DisableCache:         true,
+		CoalesceRESTRequests: true,
+		HttpClient: doerMock(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/api/v9/users/1" {
+				<-release
+			}
+			return jsonResponse(req, http.StatusOK, &User{ID: 1, Username: "test"})
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		_, _ = client.User(1).Get()
+	}()
+	waitForFollowers(t, client, 0, "/users/1")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err = client.User(1).WithContext(ctx).Get(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the follower to stop waiting once its context is done, got %v", err)
+	}
+}
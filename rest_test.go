@@ -4,11 +4,15 @@
 package disgord
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"io/ioutil"
 	"net/http"
 	"testing"
 
 	"github.com/andersfylling/disgord/internal/httd"
+	"github.com/andersfylling/disgord/json"
 )
 
 func verifyQueryString(t *testing.T, params URLQueryStringer, wants string) {
@@ -34,6 +38,43 @@ func (gm *reqMocker) Do(ctx context.Context, req *httd.Request) (*http.Response,
 
 var _ httd.Requester = (*reqMocker)(nil)
 
+// doerMock replaces the http client to test the REST methods together with the request logic in httd
+type doerMock func(req *http.Request) (*http.Response, error)
+
+func (d doerMock) Do(req *http.Request) (*http.Response, error) {
+	return d(req)
+}
+
+var _ HttpClientDoer = (doerMock)(nil)
+
+func jsonResponse(req *http.Request, code int, v interface{}) (*http.Response, error) {
+	var body []byte
+	if v != nil {
+		var err error
+		if body, err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+	return &http.Response{
+		StatusCode: code,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func newMockedClient(t *testing.T, doer HttpClientDoer) *Client {
+	client, err := NewClient(context.Background(), Config{
+		BotToken:     "testing",
+		DisableCache: true,
+		HttpClient:   doer,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
 func TestParamHolder_URLQueryString(t *testing.T) {
 	params := urlQuery{}
 	params["a"] = 45
@@ -42,3 +83,95 @@ func TestParamHolder_URLQueryString(t *testing.T) {
 	params = urlQuery{}
 	verifyQueryString(t, params, "")
 }
+
+func TestErrRest_APIErrorCode(t *testing.T) {
+	client := newMockedClient(t, doerMock(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(req, http.StatusNotFound, map[string]interface{}{
+			"code":    10008,
+			"message": "Unknown Message",
+		})
+	}))
+
+	_, err := client.Channel(1).Message(2).Get()
+	if !errors.Is(err, UnknownMessage) {
+		t.Fatalf("expected unknown message error, got %v", err)
+	}
+	if errors.Is(err, MissingAccess) {
+		t.Error("expected the error to only match its own code")
+	}
+
+	var restErr *ErrRest
+	if !errors.As(err, &restErr) || restErr.HTTPCode != http.StatusNotFound || restErr.Msg != "Unknown Message" {
+		t.Errorf("unexpected error content %+v", restErr)
+	}
+	if restErr.APICode() != UnknownMessage {
+		t.Errorf("expected code %d, got %d", UnknownMessage, restErr.APICode())
+	}
+}
+
+func TestConfig_RESTProxyURL(t *testing.T) {
+	var requested string
+	client, err := NewClient(context.Background(), Config{
+		BotToken:        "testing",
+		DisableCache:    true,
+		RESTProxyURL:    "http://127.0.0.1:8080/api",
+		RESTProxySecret: "secret",
+		HttpClient: doerMock(func(req *http.Request) (*http.Response, error) {
+			requested = req.URL.String()
+			if secret := req.Header.Get(httd.ProxySecretHeader); secret != "secret" {
+				t.Errorf("expected the proxy secret to be sent, got %q", secret)
+			}
+			return jsonResponse(req, http.StatusOK, &User{ID: 1})
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = client.User(1).Get(); err != nil {
+		t.Fatal(err)
+	}
+	if requested != "http://127.0.0.1:8080/api/v9/users/1" {
+		t.Errorf("expected the request to be sent to the proxy, got %s", requested)
+	}
+}
+
+func TestFlag_priority(t *testing.T) {
+	priorities := make(map[string]httd.Priority)
+	client, err := NewClient(context.Background(), Config{
+		BotToken:     "testing",
+		DisableCache: true,
+		HttpClient: doerMock(func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(req, http.StatusOK, &User{ID: 1})
+		}),
+		RESTInterceptors: []RESTInterceptor{RESTInterceptorFunc(func(x *RESTExchange, next RESTRoundTrip) error {
+			priorities[x.Request.Endpoint] = x.Request.Priority
+			return next(x)
+		})},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = client.User(1).Get(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.User(2).WithFlags(PriorityLow).Get(); err != nil {
+		t.Fatal(err)
+	}
+	interaction := &InteractionCreate{ID: 3, Token: "token"}
+	if err = client.SendInteractionResponse(context.Background(), interaction, &CreateInteractionResponse{Type: InteractionCallbackPong}); err != nil {
+		t.Fatal(err)
+	}
+
+	expects := map[string]httd.Priority{
+		"/users/1":                       httd.PriorityNormal,
+		"/users/2":                       httd.PriorityLow,
+		"/interactions/3/token/callback": httd.PriorityHigh,
+	}
+	for endpoint, priority := range expects {
+		if got, ok := priorities[endpoint]; !ok || got != priority {
+			t.Errorf("expected %s to have priority %d, got %d", endpoint, priority, got)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"sort"
 
+	"github.com/andersfylling/disgord/cdn"
 	"github.com/andersfylling/disgord/internal/endpoint"
 	"github.com/andersfylling/disgord/internal/httd"
 )
@@ -46,6 +47,7 @@ type Role struct {
 	Permissions PermissionBit `json:"permissions"`
 	Managed     bool          `json:"managed"`
 	Mentionable bool          `json:"mentionable"`
+	Icon        string        `json:"icon,omitempty"` // icon hash
 	guildID     Snowflake
 }
 
@@ -64,6 +66,11 @@ func (r *Role) Mention() string {
 	return "<@&" + r.ID.String() + ">"
 }
 
+// IconURL returns a link to the role icon with the given size.
+func (r *Role) IconURL(size int) (string, error) {
+	return cdn.RoleIcon(r.ID, r.Icon).PreferredURL(size, false)
+}
+
 // SetGuildID link role to a guild before running session.SaveToDiscord(*Role)
 func (r *Role) SetGuildID(id Snowflake) {
 	r.guildID = id
@@ -125,6 +132,10 @@ func (g guildRoleQueryBuilder) Delete() error {
 		return err
 	}
 
+	if err := g.client.preflight(g.gid, 0, PermissionManageRoles); err != nil {
+		return err
+	}
+
 	r := g.client.newRESTRequest(&httd.Request{
 		Method:   http.MethodDelete,
 		Endpoint: endpoint.GuildRole(g.gid, g.roleID),
@@ -144,6 +155,10 @@ func (g guildRoleQueryBuilder) Update(params *UpdateRole) (*Role, error) {
 		return nil, err
 	}
 
+	if err := g.client.preflight(g.gid, 0, PermissionManageRoles); err != nil {
+		return nil, err
+	}
+
 	r := g.client.newRESTRequest(&httd.Request{
 		Method:      http.MethodPatch,
 		Ctx:         g.ctx,
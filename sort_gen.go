@@ -77,8 +77,12 @@ func derefSliceP(v interface{}) (s interface{}) {
 		s = *t
 	case *[]*AuditLogEntry:
 		s = *t
+	case *[]*AuditLogEntryView:
+		s = *t
 	case *[]*AuditLogOption:
 		s = *t
+	case *[]*IterateAuditLogs:
+		s = *t
 	case *[]*BasicCache:
 		s = *t
 	case *[]*AllowedMentions:
@@ -107,10 +111,18 @@ func derefSliceP(v interface{}) (s interface{}) {
 		s = *t
 	case *[]*GroupDMParticipant:
 		s = *t
+	case *[]*IterateArchivedThreads:
+		s = *t
 	case *[]*PartialChannel:
 		s = *t
 	case *[]*PermissionOverwrite:
 		s = *t
+	case *[]*PurgeFailure:
+		s = *t
+	case *[]*PurgeMessages:
+		s = *t
+	case *[]*PurgeResult:
+		s = *t
 	case *[]*UpdateChannel:
 		s = *t
 	case *[]*UpdateChannelPermissions:
@@ -155,6 +167,8 @@ func derefSliceP(v interface{}) (s interface{}) {
 		s = *t
 	case *[]*ChannelUpdate:
 		s = *t
+	case *[]*GuildAuditLogEntryCreate:
+		s = *t
 	case *[]*GuildBanAdd:
 		s = *t
 	case *[]*GuildBanRemove:
@@ -265,6 +279,8 @@ func derefSliceP(v interface{}) (s interface{}) {
 		s = *t
 	case *[]*GetAuditLogs:
 		s = *t
+	case *[]*GetBans:
+		s = *t
 	case *[]*GetMembers:
 		s = *t
 	case *[]*GetPruneMembersCount:
@@ -279,6 +295,8 @@ func derefSliceP(v interface{}) (s interface{}) {
 		s = *t
 	case *[]*IntegrationAccount:
 		s = *t
+	case *[]*IterateBans:
+		s = *t
 	case *[]*Member:
 		s = *t
 	case *[]*PartialBan:
@@ -307,6 +325,8 @@ func derefSliceP(v interface{}) (s interface{}) {
 		s = *t
 	case *[]*GuildScheduledEventUsers:
 		s = *t
+	case *[]*IterateScheduledEventMembers:
+		s = *t
 	case *[]*ScheduledEventEntityMetadata:
 		s = *t
 	case *[]*UpdateScheduledEvent:
@@ -325,6 +345,8 @@ func derefSliceP(v interface{}) (s interface{}) {
 		s = *t
 	case *[]*InviteMetadata:
 		s = *t
+	case *[]*Pagination:
+		s = *t
 	case *[]*UpdateMember:
 		s = *t
 	case *[]*CreateThread:
@@ -351,6 +373,8 @@ func derefSliceP(v interface{}) (s interface{}) {
 		s = *t
 	case *[]*GetReactionURL:
 		s = *t
+	case *[]*IterateReactions:
+		s = *t
 	case *[]*Reaction:
 		s = *t
 	case *[]*Ctrl:
@@ -383,6 +407,8 @@ func derefSliceP(v interface{}) (s interface{}) {
 		s = *t
 	case *[]*GetCurrentUserGuilds:
 		s = *t
+	case *[]*IterateCurrentUserGuilds:
+		s = *t
 	case *[]*UpdateUser:
 		s = *t
 	case *[]*User:
@@ -667,6 +693,12 @@ func sortByGuildID(v interface{}, flags Flag) {
 		} else {
 			less = func(i, j int) bool { return s[i].GuildID < s[j].GuildID }
 		}
+	case []*GuildAuditLogEntryCreate:
+		if descending {
+			less = func(i, j int) bool { return s[i].GuildID > s[j].GuildID }
+		} else {
+			less = func(i, j int) bool { return s[i].GuildID < s[j].GuildID }
+		}
 	case []*GuildBanAdd:
 		if descending {
 			less = func(i, j int) bool { return s[i].GuildID > s[j].GuildID }
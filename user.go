@@ -5,8 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"strings"
 
+	"github.com/andersfylling/disgord/cdn"
 	"github.com/andersfylling/disgord/internal/endpoint"
 	"github.com/andersfylling/disgord/internal/httd"
 )
@@ -218,20 +218,12 @@ func (u *User) Mention() string {
 }
 
 // AvatarURL returns a link to the Users avatar with the given size.
+// Users without an avatar gets a link to their default avatar instead.
 func (u *User) AvatarURL(size int, preferGIF bool) (url string, err error) {
-	if size > 2048 || size < 16 || (size&(size-1)) > 0 {
-		return "", errors.New("image size can be any power of two between 16 and 2048")
-	}
-
 	if u.Avatar == "" {
-		url = fmt.Sprintf("https://cdn.discordapp.com/embed/avatars/%d.png?size=%d", u.Discriminator%5, size)
-	} else if strings.HasPrefix(u.Avatar, "a_") && preferGIF {
-		url = fmt.Sprintf("https://cdn.discordapp.com/avatars/%d/%s.gif?size=%d", u.ID, u.Avatar, size)
-	} else {
-		url = fmt.Sprintf("https://cdn.discordapp.com/avatars/%d/%s.webp?size=%d", u.ID, u.Avatar, size)
+		return cdn.DefaultUserAvatar(int(u.Discriminator)).URL(cdn.PNG, size)
 	}
-
-	return
+	return cdn.UserAvatar(u.ID, u.Avatar).PreferredURL(size, preferGIF)
 }
 
 // Tag formats the user to Anders#1234
@@ -401,6 +393,10 @@ type CurrentUserQueryBuilder interface {
 	// Requires the Guilds OAuth2 scope.
 	GetGuilds(params *GetCurrentUserGuilds) (ret []*Guild, err error)
 
+	// IterateGuilds walks the guilds the current user is a member of, ordered by guild ID.
+	// The default direction is forward.
+	IterateGuilds(params *IterateCurrentUserGuilds) *GuildIterator
+
 	Update(params *UpdateUser) (*User, error)
 
 	// CreateGroupDM Create a new group DM channel with multiple Users. Returns a DM channel object.
@@ -516,8 +512,13 @@ var _ URLQueryStringer = (*GetCurrentUserGuilds)(nil)
 //	                        Guilds a non-bot user can join. Therefore, pagination is not needed for
 //	                        integrations that need to get a list of Users' Guilds.
 func (c currentUserQueryBuilder) GetGuilds(params *GetCurrentUserGuilds) (ret []*Guild, err error) {
+	var query string
+	if params != nil {
+		query += params.URLQueryString()
+	}
+
 	r := c.client.newRESTRequest(&httd.Request{
-		Endpoint: endpoint.UserMeGuilds(),
+		Endpoint: endpoint.UserMeGuilds() + query,
 		Ctx:      c.ctx,
 	}, c.flags)
 	r.factory = func() interface{} {
@@ -536,6 +537,60 @@ func (c currentUserQueryBuilder) GetGuilds(params *GetCurrentUserGuilds) (ret []
 	return nil, errors.New("unable to cast guild slice")
 }
 
+// IterateCurrentUserGuilds configures a GuildIterator.
+type IterateCurrentUserGuilds struct {
+	Pagination
+
+	// Until stops the iteration when it returns true. The guild is not returned.
+	Until func(guild *Guild) bool
+}
+
+// GuildIterator walks a list of partial guilds. See CurrentUserQueryBuilder.IterateGuilds.
+type GuildIterator struct {
+	it *cursorIterator
+}
+
+// Next returns the next guild, or ErrIteratorDone after the last guild.
+func (g *GuildIterator) Next(ctx context.Context) (*Guild, error) {
+	item, err := g.it.next(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return item.(*Guild), nil
+}
+
+// IterateGuilds creates a iterator for the guilds the current user is a member of.
+func (c currentUserQueryBuilder) IterateGuilds(params *IterateCurrentUserGuilds) *GuildIterator {
+	const pageSizeMax = 200
+	if params == nil {
+		params = &IterateCurrentUserGuilds{}
+	}
+
+	fetch := func(ctx context.Context, before, after Snowflake, limit int) ([]interface{}, error) {
+		guilds, err := c.WithContext(ctx).GetGuilds(&GetCurrentUserGuilds{Before: before, After: after, Limit: limit})
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, len(guilds))
+		for i := range guilds {
+			items[i] = guilds[i]
+		}
+		return items, nil
+	}
+	id := func(item interface{}) Snowflake {
+		return item.(*Guild).ID
+	}
+
+	it := newCursorIterator(params.Pagination, PaginateForward, pageSizeMax, fetch, id)
+	if params.Until != nil {
+		until := params.Until
+		it.until = func(item interface{}) bool {
+			return until(item.(*Guild))
+		}
+	}
+	return &GuildIterator{it: it}
+}
+
 // CreateGroupDM required JSON params for func CreateGroupDM
 // https://discord.com/developers/docs/resources/user#create-group-dm
 type CreateGroupDM struct {
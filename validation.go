@@ -0,0 +1,183 @@
+package disgord
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Message and embed limits enforced by Discord. Text is measured in characters.
+//
+// https://discord.com/developers/docs/resources/channel#embed-object-embed-limits
+const (
+	MessageContentLimit     = 2000
+	MessageEmbedsLimit      = 10
+	MessageAttachmentsLimit = 10
+
+	// EmbedTotalLimit is the combined length of the title, description, field names, field values,
+	// footer text and author name of every embed in a message.
+	EmbedTotalLimit       = 6000
+	EmbedTitleLimit       = 256
+	EmbedDescriptionLimit = 4096
+	EmbedFieldsLimit      = 25
+	EmbedFieldNameLimit   = 256
+	EmbedFieldValueLimit  = 1024
+	EmbedFooterTextLimit  = 2048
+	EmbedAuthorNameLimit  = 256
+
+	WebhookUsernameLimit = 80
+)
+
+// PayloadViolation describes a single field of a request payload exceeding a Discord limit.
+type PayloadViolation struct {
+	// Path to the field, such as "embeds.0.fields.2.value". Empty when the limit applies to the whole payload.
+	Path  string
+	Limit int
+
+	// Length of the field in characters, or the number of items for lists
+	Length int
+}
+
+func (v *PayloadViolation) Error() string {
+	path := v.Path
+	if path == "" {
+		path = "payload"
+	}
+	return fmt.Sprintf("%s: length %d exceeds the limit of %d", path, v.Length, v.Limit)
+}
+
+// ErrInvalidPayload is returned before a request is sent when the payload exceeds Discord limits.
+// It holds every violation found, and matches ErrIllegalValue with errors.Is.
+type ErrInvalidPayload struct {
+	Violations []*PayloadViolation
+}
+
+var _ error = (*ErrInvalidPayload)(nil)
+
+func (e *ErrInvalidPayload) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		msgs = append(msgs, violation.Error())
+	}
+	return "invalid payload: " + strings.Join(msgs, "; ")
+}
+
+func (e *ErrInvalidPayload) Is(target error) bool {
+	return target == ErrIllegalValue
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// payloadValidator collects the violations of a payload.
+type payloadValidator struct {
+	violations []*PayloadViolation
+}
+
+func (v *payloadValidator) limit(path string, length, limit int) {
+	if length > limit {
+		v.violations = append(v.violations, &PayloadViolation{Path: path, Limit: limit, Length: length})
+	}
+}
+
+// text validates the length of a string and returns the length in characters.
+func (v *payloadValidator) text(path, s string, limit int) int {
+	length := utf8.RuneCountInString(s)
+	v.limit(path, length, limit)
+	return length
+}
+
+// embed validates a single embed and returns the length that counts towards EmbedTotalLimit.
+func (v *payloadValidator) embed(path string, e *Embed) (total int) {
+	if e == nil {
+		return 0
+	}
+
+	total += v.text(joinPath(path, "title"), e.Title, EmbedTitleLimit)
+	total += v.text(joinPath(path, "description"), e.Description, EmbedDescriptionLimit)
+	v.limit(joinPath(path, "fields"), len(e.Fields), EmbedFieldsLimit)
+	for i, field := range e.Fields {
+		if field == nil {
+			continue
+		}
+		fieldPath := joinPath(path, "fields."+strconv.Itoa(i))
+		total += v.text(joinPath(fieldPath, "name"), field.Name, EmbedFieldNameLimit)
+		total += v.text(joinPath(fieldPath, "value"), field.Value, EmbedFieldValueLimit)
+	}
+	if e.Footer != nil {
+		total += v.text(joinPath(path, "footer.text"), e.Footer.Text, EmbedFooterTextLimit)
+	}
+	if e.Author != nil {
+		total += v.text(joinPath(path, "author.name"), e.Author.Name, EmbedAuthorNameLimit)
+	}
+	return total
+}
+
+// embeds validates the embeds of a message, including their combined length. The total holds
+// the length already used by other embeds in the message.
+func (v *payloadValidator) embeds(path string, embeds []*Embed, total int) {
+	v.limit(path, len(embeds), MessageEmbedsLimit)
+
+	for i, embed := range embeds {
+		total += v.embed(joinPath(path, strconv.Itoa(i)), embed)
+	}
+	v.limit(path, total, EmbedTotalLimit)
+}
+
+func (v *payloadValidator) err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+	return &ErrInvalidPayload{Violations: v.violations}
+}
+
+// FindErrors returns a *ErrInvalidPayload when the embed exceeds any Discord limit.
+func (e *Embed) FindErrors() error {
+	v := &payloadValidator{}
+	v.limit("", v.embed("", e), EmbedTotalLimit)
+	return v.err()
+}
+
+// FindErrors returns a *ErrInvalidPayload when the message exceeds any Discord limit.
+func (p *CreateMessage) FindErrors() error {
+	v := &payloadValidator{}
+	v.text("content", p.Content, MessageContentLimit)
+	v.embeds("embeds", p.Embeds, v.embed("embed", p.Embed))
+	v.limit("files", len(p.Files), MessageAttachmentsLimit)
+	return v.err()
+}
+
+// FindErrors returns a *ErrInvalidPayload when the message update exceeds any Discord limit.
+func (p *UpdateMessage) FindErrors() error {
+	v := &payloadValidator{}
+	if p.Content != nil {
+		v.text("content", *p.Content, MessageContentLimit)
+	}
+	if p.Embeds != nil {
+		v.embeds("embeds", *p.Embeds, 0)
+	}
+	return v.err()
+}
+
+// FindErrors returns a *ErrInvalidPayload when the webhook message exceeds any Discord limit.
+func (p *ExecuteWebhook) FindErrors() error {
+	v := &payloadValidator{}
+	v.text("content", p.Content, MessageContentLimit)
+	v.text("username", p.Username, WebhookUsernameLimit)
+	v.embeds("embeds", p.Embeds, 0)
+	return v.err()
+}
+
+// FindErrors returns a *ErrInvalidPayload when the interaction response exceeds any Discord limit.
+func (p *CreateInteractionResponseData) FindErrors() error {
+	v := &payloadValidator{}
+	v.text("content", p.Content, MessageContentLimit)
+	v.embeds("embeds", p.Embeds, 0)
+	v.limit("attachments", len(p.Attachments)+len(p.Files), MessageAttachmentsLimit)
+	return v.err()
+}
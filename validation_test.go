@@ -0,0 +1,84 @@
+//go:build !integration
+// +build !integration
+
+package disgord
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCreateMessage_FindErrors(t *testing.T) {
+	fields := make([]*EmbedField, EmbedFieldsLimit+1)
+	for i := range fields {
+		fields[i] = &EmbedField{Name: "name", Value: "value"}
+	}
+	fields[3].Value = strings.Repeat("a", EmbedFieldValueLimit+1)
+
+	params := &CreateMessage{
+		Content: strings.Repeat("a", MessageContentLimit+1),
+		Embeds: []*Embed{
+			{Title: "ok"},
+			{Title: strings.Repeat("a", EmbedTitleLimit+1), Fields: fields},
+			{Description: strings.Repeat("a", EmbedDescriptionLimit), Footer: &EmbedFooter{Text: strings.Repeat("a", EmbedFooterTextLimit+1)}},
+		},
+	}
+
+	err := params.FindErrors()
+	if !errors.Is(err, ErrIllegalValue) {
+		t.Fatalf("expected illegal value error, got %v", err)
+	}
+	var payloadErr *ErrInvalidPayload
+	if !errors.As(err, &payloadErr) {
+		t.Fatalf("expected invalid payload error, got %v", err)
+	}
+
+	expected := []string{
+		"content",
+		"embeds.1.title",
+		"embeds.1.fields",
+		"embeds.1.fields.3.value",
+		"embeds.2.footer.text",
+		"embeds",
+	}
+	if len(payloadErr.Violations) != len(expected) {
+		t.Fatalf("expected %d violations, got %s", len(expected), err)
+	}
+	for i, path := range expected {
+		if payloadErr.Violations[i].Path != path {
+			t.Errorf("expected violation %d to have path %q, got %q", i, path, payloadErr.Violations[i].Path)
+		}
+	}
+
+	if err = (&CreateMessage{Content: "ü", Embeds: []*Embed{{Title: "title"}}}).FindErrors(); err != nil {
+		t.Errorf("expected a valid message, got %v", err)
+	}
+}
+
+func TestEmbed_FindErrors(t *testing.T) {
+	embed := &Embed{Author: &EmbedAuthor{Name: strings.Repeat("ø", EmbedAuthorNameLimit)}}
+	if err := embed.FindErrors(); err != nil {
+		t.Errorf("text is measured in characters, got %v", err)
+	}
+
+	embed.Author.Name += "ø"
+	err := embed.FindErrors()
+	var payloadErr *ErrInvalidPayload
+	if !errors.As(err, &payloadErr) || len(payloadErr.Violations) != 1 || payloadErr.Violations[0].Path != "author.name" {
+		t.Errorf("expected the author name to be too long, got %v", err)
+	}
+}
+
+func TestChannelQueryBuilder_CreateMessage_invalidPayload(t *testing.T) {
+	client := newMockedClient(t, doerMock(func(req *http.Request) (*http.Response, error) {
+		t.Fatal("the request must not be sent")
+		return nil, nil
+	}))
+
+	_, err := client.Channel(1).CreateMessage(&CreateMessage{Content: strings.Repeat("a", MessageContentLimit+1)})
+	if !errors.Is(err, ErrIllegalValue) {
+		t.Errorf("expected illegal value error, got %v", err)
+	}
+}
@@ -327,6 +327,9 @@ func (w webhookWithTokenQueryBuilder) Execute(params *ExecuteWebhook, wait *bool
 	if err := w.validate(); err != nil {
 		return nil, err
 	}
+	if err := params.FindErrors(); err != nil {
+		return nil, err
+	}
 
 	var contentType string
 	if params.File == nil {
@@ -428,6 +431,9 @@ func (w *webhookWithTokenQueryBuilder) EditMessage(params *ExecuteWebhook, messa
 	if err := w.validate(); err != nil {
 		return nil, err
 	}
+	if err := params.FindErrors(); err != nil {
+		return nil, err
+	}
 
 	var contentType string
 	if params.File == nil {